@@ -0,0 +1,253 @@
+package ramaris
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached successful GET response body and its validators.
+type CacheEntry struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+}
+
+// Cache stores GET responses keyed by request URL. Implementations must be
+// safe for concurrent use. Because keys do not include the API key, a Cache
+// should not be shared between clients authenticated as different users.
+type Cache interface {
+	// Get returns the entry for key, or nil if there is none. fresh reports
+	// whether the entry may be served without contacting the API; stale
+	// entries are revalidated with a conditional request.
+	Get(key string) (entry *CacheEntry, fresh bool)
+	// Set stores entry under key, replacing any previous entry and
+	// restarting its freshness lifetime.
+	Set(key string, entry *CacheEntry)
+	// Delete removes the entry for key, if any.
+	Delete(key string)
+}
+
+// PrefixCache is a Cache that can also remove every entry whose key starts
+// with a prefix. Mutations use it to evict the paged and filtered variants of
+// the collections they change; with a plain Cache only the unqualified
+// collection URLs are evicted, and other variants are served until they go
+// stale. MemoryCache implements PrefixCache.
+type PrefixCache interface {
+	Cache
+	// DeletePrefix removes every entry whose key starts with prefix.
+	DeletePrefix(prefix string)
+}
+
+// invalidates lists, per mutating operation, the paths it changes besides its
+// own path and the collections above it. A {param} segment is replaced with
+// the value at the same place in the operation's route.
+var invalidates = map[string][]string{
+	"CreateStrategy":           {"/me/profile"},
+	"UpdateStrategy":           {"/strategies/me/watchlist"},
+	"DeleteStrategy":           {"/strategies/me/watchlist", "/me/profile", "/strategies/{shareID}/wallets", "/strategies/{shareID}/notifications"},
+	"AddWalletToStrategy":      {"/strategies/me/watchlist"},
+	"RemoveWalletFromStrategy": {"/strategies/me/watchlist", "/strategies/{shareID}/wallets", "/strategies/{shareID}/notifications"},
+	"AddToWatchlist":           {"/me/profile"},
+	"RemoveFromWatchlist":      {"/me/profile"},
+	"FollowWallet":             {"/me/followed-wallets", "/me/profile"},
+	"UnfollowWallet":           {"/me/followed-wallets", "/me/profile"},
+}
+
+// WithCache enables response caching for GET requests. Fresh entries are
+// served without a request. Stale entries that carry an ETag or
+// Last-Modified validator are revalidated with If-None-Match or
+// If-Modified-Since, and a 304 Not Modified is served from the cache.
+func WithCache(cache Cache) Option {
+	return func(c *Client) { c.cache = cache }
+}
+
+// cachedEntry returns the cached response for key if it is fresh. Otherwise
+// it returns the stale entry to revalidate, if it has validators.
+func (c *Client) cachedEntry(key string) (resp *http.Response, stale *CacheEntry) {
+	if c.cache == nil {
+		return nil, nil
+	}
+	entry, fresh := c.cache.Get(key)
+	if entry == nil {
+		return nil, nil
+	}
+	if fresh {
+		return entry.response(), nil
+	}
+	if entry.ETag != "" || entry.LastModified != "" {
+		return nil, entry
+	}
+	return nil, nil
+}
+
+// storeResponse buffers a successful response into the cache, unless the API
+// marked it no-store, and returns a response with a replayable body.
+func (c *Client) storeResponse(key string, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("ramaris: failed to read response: %w", err)
+	}
+
+	if !strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		c.cache.Set(key, &CacheEntry{
+			Body:         body,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		})
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// invalidate evicts the cached responses that a successful mutation may have
+// changed: those for its own path, for each collection above it (so that
+// removing /strategies/me/watchlist/abc evicts /strategies/me/watchlist), and
+// for the other paths listed in invalidates, each with its query variants.
+func (c *Client) invalidate(call *apiCall) {
+	paths := []string{call.path}
+	for p := call.path; strings.LastIndex(p, "/") > 0; {
+		p = p[:strings.LastIndex(p, "/")]
+		paths = append(paths, p)
+	}
+	op, template := lookupRoute(call.method, call.path)
+	params := routeParams(template, call.path)
+	for _, p := range invalidates[op] {
+		paths = append(paths, params.Replace(p))
+	}
+
+	pc, _ := c.cache.(PrefixCache)
+	for _, p := range paths {
+		key := c.buildURL(p, nil)
+		c.cache.Delete(key)
+		if pc != nil {
+			pc.DeletePrefix(key + "?")
+		}
+	}
+}
+
+// routeParams returns a replacer that fills the {param} segments of template
+// with their values from path.
+func routeParams(template, path string) *strings.Replacer {
+	var oldnew []string
+	segments := strings.Split(path, "/")
+	for i, p := range strings.Split(template, "/") {
+		if strings.HasPrefix(p, "{") && i < len(segments) {
+			oldnew = append(oldnew, p, segments[i])
+		}
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+func (e *CacheEntry) response() *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(e.Body)),
+	}
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used
+// entry once full. Entries are fresh for a fixed TTL after being stored.
+type MemoryCache struct {
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key      string
+	entry    *CacheEntry
+	storedAt time.Time
+}
+
+// NewMemoryCache creates a MemoryCache holding at most maxEntries entries
+// (unbounded if maxEntries <= 0). A ttl of zero makes every entry stale, so
+// each request is revalidated.
+func NewMemoryCache(maxEntries int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	m.ll.MoveToFront(el)
+	item := el.Value.(*memoryCacheItem)
+	return item.entry, m.now().Sub(item.storedAt) < m.ttl
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.ll.MoveToFront(el)
+		item := el.Value.(*memoryCacheItem)
+		item.entry = entry
+		item.storedAt = m.now()
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryCacheItem{key: key, entry: entry, storedAt: m.now()})
+	if m.maxEntries > 0 && m.ll.Len() > m.maxEntries {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete implements Cache.
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.ll.Remove(el)
+		delete(m.items, key)
+	}
+}
+
+// DeletePrefix implements PrefixCache.
+func (m *MemoryCache) DeletePrefix(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, el := range m.items {
+		if strings.HasPrefix(key, prefix) {
+			m.ll.Remove(el)
+			delete(m.items, key)
+		}
+	}
+}
+
+// Len returns the number of cached entries.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
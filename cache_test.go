@@ -0,0 +1,282 @@
+package ramaris
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	m := NewMemoryCache(2, time.Minute)
+	m.Set("a", &CacheEntry{Body: []byte("a")})
+	m.Set("b", &CacheEntry{Body: []byte("b")})
+	m.Get("a") // a is now most recently used
+	m.Set("c", &CacheEntry{Body: []byte("c")})
+
+	if e, _ := m.Get("b"); e != nil {
+		t.Error("Get(b) != nil, want evicted")
+	}
+	if e, _ := m.Get("a"); e == nil {
+		t.Error("Get(a) = nil, want retained")
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+
+	m.Delete("a")
+	if e, _ := m.Get("a"); e != nil {
+		t.Error("Get(a) after Delete != nil")
+	}
+}
+
+func TestMemoryCache_TTL(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	m := NewMemoryCache(0, time.Minute)
+	m.now = func() time.Time { return now }
+	m.Set("k", &CacheEntry{Body: []byte("v")})
+
+	if e, fresh := m.Get("k"); e == nil || !fresh {
+		t.Errorf("Get() = %v, %v, want fresh entry", e, fresh)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if e, fresh := m.Get("k"); e == nil || fresh {
+		t.Errorf("Get() = %v, %v, want stale entry", e, fresh)
+	}
+
+	m.Set("k", &CacheEntry{Body: []byte("v")})
+	if _, fresh := m.Get("k"); !fresh {
+		t.Error("Get() after Set fresh = false, want true")
+	}
+}
+
+const cachedStrategyJSON = `{"data":{"id":1,"shareId":"abc","name":"Cached","createdAt":"2025-01-01T00:00:00Z","creator":{"nickname":"a"},"stats":{},"status":"ACTIVE"}}`
+
+func TestClient_CacheFreshHit(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, cachedStrategyJSON)
+	})
+	WithCache(NewMemoryCache(10, time.Minute))(c)
+
+	for i := 0; i < 3; i++ {
+		s, err := c.GetStrategy(context.Background(), "abc")
+		if err != nil {
+			t.Fatalf("GetStrategy() error: %v", err)
+		}
+		if s.Name != "Cached" {
+			t.Errorf("Name = %q, want %q", s.Name, "Cached")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestClient_CacheRevalidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		value     string
+		condition string
+	}{
+		{"etag", "ETag", `"v1"`, "If-None-Match"},
+		{"last modified", "Last-Modified", "Wed, 01 Jan 2025 00:00:00 GMT", "If-Modified-Since"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			var gotCondition string
+			_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				gotCondition = r.Header.Get(tt.condition)
+				if gotCondition == tt.value {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set(tt.header, tt.value)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, cachedStrategyJSON)
+			})
+			WithCache(NewMemoryCache(10, 0))(c)
+
+			for i := 0; i < 2; i++ {
+				s, err := c.GetStrategy(context.Background(), "abc")
+				if err != nil {
+					t.Fatalf("GetStrategy() #%d error: %v", i, err)
+				}
+				if s.Name != "Cached" {
+					t.Errorf("Name = %q, want %q", s.Name, "Cached")
+				}
+			}
+			if calls != 2 {
+				t.Errorf("calls = %d, want 2", calls)
+			}
+			if gotCondition != tt.value {
+				t.Errorf("%s = %q, want %q", tt.condition, gotCondition, tt.value)
+			}
+		})
+	}
+}
+
+func TestClient_CacheNoStore(t *testing.T) {
+	cache := NewMemoryCache(10, time.Minute)
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, cachedStrategyJSON)
+	})
+	WithCache(cache)(c)
+
+	if _, err := c.GetStrategy(context.Background(), "abc"); err != nil {
+		t.Fatalf("GetStrategy() error: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("cache.Len() = %d, want 0", cache.Len())
+	}
+}
+
+func TestClient_CacheSkipsErrors(t *testing.T) {
+	cache := NewMemoryCache(10, time.Minute)
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":"NOT_FOUND","message":"nope"}}`)
+	})
+	WithCache(cache)(c)
+
+	if _, err := c.GetStrategy(context.Background(), "abc"); err == nil {
+		t.Fatal("GetStrategy() error = nil, want error")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("cache.Len() = %d, want 0", cache.Len())
+	}
+}
+
+func TestClient_CacheInvalidatedByMutation(t *testing.T) {
+	const page = `{"data":[],"pagination":{"page":2,"pageSize":10,"totalItems":0,"totalPages":0}}`
+	tests := []struct {
+		name   string
+		list   func(context.Context, *Client) error
+		mutate func(context.Context, *Client) error
+	}{
+		{
+			name:   "add to watchlist",
+			list:   func(ctx context.Context, c *Client) error { _, err := c.ListWatchlist(ctx, nil); return err },
+			mutate: func(ctx context.Context, c *Client) error { _, err := c.AddToWatchlist(ctx, "abc"); return err },
+		},
+		{
+			name: "remove from watchlist",
+			list: func(ctx context.Context, c *Client) error {
+				_, err := c.ListWatchlist(ctx, &ListOptions{Page: 2, PageSize: 10})
+				return err
+			},
+			mutate: func(ctx context.Context, c *Client) error { return c.RemoveFromWatchlist(ctx, "abc") },
+		},
+		{
+			name: "follow wallet",
+			list: func(ctx context.Context, c *Client) error {
+				_, err := c.ListFollowedWallets(ctx, &ListOptions{Page: 2})
+				return err
+			},
+			mutate: func(ctx context.Context, c *Client) error { _, err := c.FollowWallet(ctx, 456); return err },
+		},
+		{
+			name:   "unfollow wallet",
+			list:   func(ctx context.Context, c *Client) error { _, err := c.ListFollowedWallets(ctx, nil); return err },
+			mutate: func(ctx context.Context, c *Client) error { return c.UnfollowWallet(ctx, 456) },
+		},
+		{
+			name: "remove wallet from strategy",
+			list: func(ctx context.Context, c *Client) error {
+				_, err := c.ListStrategyWallets(ctx, "abc", &ListOptions{Page: 2})
+				return err
+			},
+			mutate: func(ctx context.Context, c *Client) error { return c.RemoveWalletFromStrategy(ctx, "abc", 456) },
+		},
+		{
+			name: "remove wallet from strategy notifications",
+			list: func(ctx context.Context, c *Client) error {
+				_, err := c.ListStrategyNotifications(ctx, "abc", nil)
+				return err
+			},
+			mutate: func(ctx context.Context, c *Client) error { return c.RemoveWalletFromStrategy(ctx, "abc", 456) },
+		},
+		{
+			name: "delete strategy wallets",
+			list: func(ctx context.Context, c *Client) error {
+				_, err := c.ListStrategyWallets(ctx, "abc", nil)
+				return err
+			},
+			mutate: func(ctx context.Context, c *Client) error { return c.DeleteStrategy(ctx, "abc") },
+		},
+		{
+			name: "delete strategy notifications",
+			list: func(ctx context.Context, c *Client) error {
+				_, err := c.ListStrategyNotifications(ctx, "abc", &NotificationListOptions{ListOptions: ListOptions{Page: 2}})
+				return err
+			},
+			mutate: func(ctx context.Context, c *Client) error { return c.DeleteStrategy(ctx, "abc") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodGet:
+					calls++
+					fmt.Fprint(w, page)
+				case http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"data":{"shareId":"abc","walletId":456}}`)
+				}
+			})
+			WithCache(NewMemoryCache(10, time.Minute))(c)
+			ctx := context.Background()
+
+			for _, step := range []func(context.Context, *Client) error{tt.list, tt.list, tt.mutate, tt.list} {
+				if err := step(ctx, c); err != nil {
+					t.Fatalf("error: %v", err)
+				}
+			}
+			if calls != 2 {
+				t.Errorf("GET calls = %d, want 2: one cached read, one after the mutation", calls)
+			}
+		})
+	}
+}
+
+func TestClient_CacheInvalidationKeepsUnrelated(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		calls++
+		fmt.Fprint(w, `{"data":{"id":1}}`)
+	})
+	WithCache(NewMemoryCache(10, time.Minute))(c)
+	ctx := context.Background()
+
+	if _, err := c.GetWallet(ctx, 1); err != nil {
+		t.Fatalf("GetWallet() error: %v", err)
+	}
+	if err := c.RemoveFromWatchlist(ctx, "abc"); err != nil {
+		t.Fatalf("RemoveFromWatchlist() error: %v", err)
+	}
+	if _, err := c.GetWallet(ctx, 1); err != nil {
+		t.Fatalf("GetWallet() error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("GET calls = %d, want 1", calls)
+	}
+}
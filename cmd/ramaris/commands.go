@@ -0,0 +1,782 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ramaris "github.com/ramaris-app/go-sdk"
+)
+
+// command is a CLI subcommand. name may be one or two words.
+type command struct {
+	name string
+	args string
+	help string
+	run  func(ctx context.Context, c *ramaris.Client, out *output, args []string) error
+}
+
+// live reports whether c prints events until interrupted, so the default
+// overall timeout does not apply to it.
+func (c *command) live() bool {
+	return c.name == "watch" || c.name == "stream"
+}
+
+var commands = []command{
+	{"health", "", "check API health", runHealth},
+	{"strategies list", "[flags]", "list strategies", listCommand("strategies list", strategyColumns, strategyRow,
+		strategyFlags, (*ramaris.Client).FilterStrategies, (*ramaris.Client).FilteredStrategies)},
+	{"strategies get", "<shareID>", "get a strategy", runGetStrategy},
+	{"strategies create", "-name <name> [flags]", "create a strategy", runCreateStrategy},
+	{"strategies update", "<shareID> [flags]", "update a strategy", runUpdateStrategy},
+	{"strategies delete", "<shareID>", "delete a strategy", runDeleteStrategy},
+	{"strategies wallets", "<shareID> [flags]", "list wallets tracked by a strategy", runStrategyWallets},
+	{"strategies add-wallet", "<shareID> <walletID> [flags]", "track a wallet in a strategy", runAddStrategyWallet},
+	{"strategies remove-wallet", "<shareID> <walletID>", "stop tracking a wallet in a strategy", runRemoveStrategyWallet},
+	{"strategies notifications", "<shareID> [flags]", "list a strategy's notifications", runStrategyNotifications},
+	{"wallets list", "[flags]", "list wallets", listCommand("wallets list", walletColumns, walletRow,
+		walletFlags, (*ramaris.Client).FilterWallets, (*ramaris.Client).FilteredWallets)},
+	{"wallets get", "<id>", "get a wallet", runGetWallet},
+	{"wallets swaps", "<id> [flags]", "list a wallet's swaps", runWalletSwaps},
+	{"wallets positions", "<id>", "list a wallet's open positions", runWalletPositions},
+	{"wallets follow", "<id>", "follow a wallet", runFollowWallet},
+	{"wallets unfollow", "<id>", "unfollow a wallet", runUnfollowWallet},
+	{"wallets following", "[flags]", "list the wallets you follow", listCommand("wallets following", followedWalletColumns, followedWalletRow,
+		pageOnly, (*ramaris.Client).ListFollowedWallets, (*ramaris.Client).FollowedWallets)},
+	{"watchlist add", "<shareID>", "add a strategy to your watchlist", runWatchlistAdd},
+	{"watchlist remove", "<shareID>", "remove a strategy from your watchlist", runWatchlistRemove},
+	{"watchlist", "[flags]", "list your watchlist", listCommand("watchlist", watchlistColumns, watchlistRow,
+		pageOnly, (*ramaris.Client).ListWatchlist, (*ramaris.Client).Watchlist)},
+	{"notifications", "[flags]", "list your notifications", listCommand("notifications", notificationColumns, notificationRow,
+		notificationFlags, (*ramaris.Client).ListNotifications, (*ramaris.Client).Notifications)},
+	{"watch", "[flags]", "poll strategies and wallets and print changes", runWatch},
+	{"stream", "[flags]", "print live events for followed wallets and watchlisted strategies", runStream},
+	{"profile", "", "show your profile", runProfile},
+	{"subscription", "", "show your subscription", runSubscription},
+}
+
+// lookup finds the command named by the leading args and returns the rest.
+func lookup(args []string) (*command, []string) {
+	for i := range commands {
+		words := strings.Fields(commands[i].name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == commands[i].name {
+			return &commands[i], args[len(words):]
+		}
+	}
+	return nil, nil
+}
+
+// parseFlags parses command flags, reporting bad input as errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+func newFlagSet(name string, out *output) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(out.errw)
+	return fs
+}
+
+// listFlags registers a list command's filter flags on fs and returns the
+// options to send, along with the pagination options embedded in them.
+type listFlags[O any] func(fs *flag.FlagSet) (opts *O, page *ramaris.ListOptions)
+
+// pageOnly is the listFlags for endpoints that only support pagination.
+func pageOnly(*flag.FlagSet) (*ramaris.ListOptions, *ramaris.ListOptions) {
+	o := &ramaris.ListOptions{}
+	return o, o
+}
+
+// listCommand builds a paginated list command. With -all, every page is
+// fetched through the iterator; otherwise a single page is requested.
+func listCommand[T, O any](
+	name string,
+	columns []string,
+	row func(T) []string,
+	flags listFlags[O],
+	list func(*ramaris.Client, context.Context, *O) (*ramaris.ListResponse[T], error),
+	all func(*ramaris.Client, context.Context, *O) iter.Seq2[T, error],
+) func(context.Context, *ramaris.Client, *output, []string) error {
+	return func(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+		fs := newFlagSet(name, out)
+		opts, page := flags(fs)
+		fs.IntVar(&page.Page, "page", 0, "page number")
+		fs.IntVar(&page.PageSize, "page-size", 0, "items per page")
+		fetchAll := fs.Bool("all", false, "fetch every page")
+		if err := parseFlags(fs, args); err != nil {
+			return err
+		}
+		if fs.NArg() > 0 {
+			return fmt.Errorf("%s: unexpected argument %q: %w", name, fs.Arg(0), errUsage)
+		}
+
+		if !*fetchAll {
+			resp, err := list(c, ctx, opts)
+			if err != nil {
+				return err
+			}
+			return writeList(out, columns, resp.Data, row)
+		}
+
+		var items []T
+		for item, err := range all(c, ctx, opts) {
+			if err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+		return writeList(out, columns, items, row)
+	}
+}
+
+func strategyFlags(fs *flag.FlagSet) (*ramaris.StrategyListOptions, *ramaris.ListOptions) {
+	o := &ramaris.StrategyListOptions{}
+	fs.Func("sort", "sort by roiPercent, lastActivityAt, createdAt or walletsTracked", func(v string) error {
+		o.SortBy = ramaris.StrategySortField(v)
+		return nil
+	})
+	fs.Func("order", "sort order: asc or desc", func(v string) error {
+		o.SortOrder = ramaris.SortOrder(v)
+		return nil
+	})
+	fs.Func("min-roi", "minimum ROI percent", floatFlag(&o.MinROI))
+	fs.Func("tag", "require tag (repeatable)", func(v string) error {
+		o.Tags = append(o.Tags, v)
+		return nil
+	})
+	fs.StringVar(&o.Creator, "creator", "", "creator nickname")
+	fs.StringVar(&o.Search, "search", "", "text search")
+	return o, &o.ListOptions
+}
+
+func walletFlags(fs *flag.FlagSet) (*ramaris.WalletListOptions, *ramaris.ListOptions) {
+	o := &ramaris.WalletListOptions{}
+	fs.Func("sort", "sort by realizedPnL, winRate, totalSwaps or createdAt", func(v string) error {
+		o.SortBy = ramaris.WalletSortField(v)
+		return nil
+	})
+	fs.Func("order", "sort order: asc or desc", func(v string) error {
+		o.SortOrder = ramaris.SortOrder(v)
+		return nil
+	})
+	fs.Func("min-win-rate", "minimum win rate (0-1)", floatFlag(&o.MinWinRate))
+	fs.Func("max-win-rate", "maximum win rate (0-1)", floatFlag(&o.MaxWinRate))
+	fs.Func("min-pnl", "minimum realized PnL", floatFlag(&o.MinRealizedPnL))
+	fs.Func("tag", "require tag (repeatable)", func(v string) error {
+		o.Tags = append(o.Tags, v)
+		return nil
+	})
+	fs.Func("exclude-tag", "exclude tag (repeatable)", func(v string) error {
+		o.ExcludeTags = append(o.ExcludeTags, v)
+		return nil
+	})
+	fs.IntVar(&o.MinOpenPositions, "min-open-positions", 0, "minimum open positions")
+	return o, &o.ListOptions
+}
+
+func notificationFlags(fs *flag.FlagSet) (*ramaris.NotificationListOptions, *ramaris.ListOptions) {
+	o := &ramaris.NotificationListOptions{}
+	fs.Func("since", "only notifications at or after this RFC 3339 time", timeFlag(&o.Since))
+	fs.Func("until", "only notifications at or before this RFC 3339 time", timeFlag(&o.Until))
+	fs.BoolFunc("unread", "only unread notifications", func(string) error {
+		o.Read = ramaris.Bool(false)
+		return nil
+	})
+	fs.Func("type", "SWAP_DETECTED, POSITION_OPENED, POSITION_CLOSED or THRESHOLD_CROSSED (repeatable)", func(v string) error {
+		o.Types = append(o.Types, ramaris.NotificationType(v))
+		return nil
+	})
+	return o, &o.ListOptions
+}
+
+// timeFlag parses an RFC 3339 time flag into *dst.
+func timeFlag(dst *time.Time) func(string) error {
+	return func(v string) error {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return err
+		}
+		*dst = t
+		return nil
+	}
+}
+
+// floatFlag parses an optional float flag into *dst.
+func floatFlag(dst **float64) func(string) error {
+	return func(v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		*dst = &f
+		return nil
+	}
+}
+
+func runHealth(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("health takes no arguments: %w", errUsage)
+	}
+	h, err := c.Health(ctx)
+	if err != nil {
+		return err
+	}
+	return writeOne(out, []string{"STATUS", "VERSION", "USER", "RATE_LIMIT", "KEY_PREFIX"}, h, func(h *ramaris.HealthStatus) []string {
+		return []string{h.Status, h.Version, h.User, strconv.Itoa(h.RateLimit.Limit), h.RateLimit.KeyPrefix}
+	})
+}
+
+func runGetStrategy(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("strategies get requires a share ID: %w", errUsage)
+	}
+	s, err := c.GetStrategy(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return writeOne(out, strategyDetailColumns, s, strategyDetailRow)
+}
+
+func runCreateStrategy(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	fs := newFlagSet("strategies create", out)
+	var req ramaris.CreateStrategyRequest
+	fs.StringVar(&req.Name, "name", "", "strategy name (required)")
+	fs.StringVar(&req.Description, "description", "", "strategy description")
+	fs.Func("tag", "tag (repeatable)", func(v string) error {
+		req.Tags = append(req.Tags, v)
+		return nil
+	})
+	fs.StringVar(&req.Status, "status", "", "ACTIVE, PAUSED or ARCHIVED (default ACTIVE)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("strategies create: unexpected argument %q: %w", fs.Arg(0), errUsage)
+	}
+
+	s, err := c.CreateStrategy(ctx, req)
+	if err != nil {
+		return err
+	}
+	return writeOne(out, strategyDetailColumns, s, strategyDetailRow)
+}
+
+func runUpdateStrategy(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("strategies update requires a share ID: %w", errUsage)
+	}
+	shareID := args[0]
+
+	fs := newFlagSet("strategies update", out)
+	var req ramaris.UpdateStrategyRequest
+	fs.Func("name", "new name", func(v string) error {
+		req.Name = &v
+		return nil
+	})
+	fs.Func("description", "new description (empty clears it)", func(v string) error {
+		req.Description = &v
+		return nil
+	})
+	fs.Func("tag", "replace tags (repeatable)", func(v string) error {
+		req.Tags = append(req.Tags, v)
+		return nil
+	})
+	fs.BoolFunc("clear-tags", "remove all tags", func(string) error {
+		req.Tags = []string{}
+		return nil
+	})
+	fs.Func("status", "ACTIVE, PAUSED or ARCHIVED", func(v string) error {
+		req.Status = &v
+		return nil
+	})
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("strategies update: unexpected argument %q: %w", fs.Arg(0), errUsage)
+	}
+
+	s, err := c.UpdateStrategy(ctx, shareID, req)
+	if err != nil {
+		return err
+	}
+	return writeOne(out, strategyDetailColumns, s, strategyDetailRow)
+}
+
+func runDeleteStrategy(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("strategies delete requires a share ID: %w", errUsage)
+	}
+	return c.DeleteStrategy(ctx, args[0])
+}
+
+func runAddStrategyWallet(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("strategies add-wallet requires a share ID and a wallet ID: %w", errUsage)
+	}
+	id, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID %q: %w", args[1], errUsage)
+	}
+
+	fs := newFlagSet("strategies add-wallet", out)
+	req := ramaris.AddStrategyWalletRequest{WalletID: id}
+	fs.Func("weight", "share of the strategy, in (0, 1]", floatFlag(&req.Weight))
+	if err := parseFlags(fs, args[2:]); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("strategies add-wallet: unexpected argument %q: %w", fs.Arg(0), errUsage)
+	}
+
+	w, err := c.AddWalletToStrategy(ctx, args[0], req)
+	if err != nil {
+		return err
+	}
+	return writeOne(out, strategyWalletColumns, w, func(w *ramaris.StrategyWallet) []string { return strategyWalletRow(*w) })
+}
+
+func runRemoveStrategyWallet(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("strategies remove-wallet requires a share ID and a wallet ID: %w", errUsage)
+	}
+	id, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID %q: %w", args[1], errUsage)
+	}
+	return c.RemoveWalletFromStrategy(ctx, args[0], id)
+}
+
+func runStrategyWallets(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("strategies wallets requires a share ID: %w", errUsage)
+	}
+	shareID := args[0]
+	return listCommand("strategies wallets", strategyWalletColumns, strategyWalletRow, pageOnly,
+		func(c *ramaris.Client, ctx context.Context, o *ramaris.ListOptions) (*ramaris.ListResponse[ramaris.StrategyWallet], error) {
+			return c.ListStrategyWallets(ctx, shareID, o)
+		},
+		func(c *ramaris.Client, ctx context.Context, o *ramaris.ListOptions) iter.Seq2[ramaris.StrategyWallet, error] {
+			return c.StrategyWallets(ctx, shareID, o)
+		},
+	)(ctx, c, out, args[1:])
+}
+
+func runStrategyNotifications(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("strategies notifications requires a share ID: %w", errUsage)
+	}
+	shareID := args[0]
+	return listCommand("strategies notifications", notificationColumns, notificationRow, notificationFlags,
+		func(c *ramaris.Client, ctx context.Context, o *ramaris.NotificationListOptions) (*ramaris.ListResponse[ramaris.Notification], error) {
+			return c.ListStrategyNotifications(ctx, shareID, o)
+		},
+		func(c *ramaris.Client, ctx context.Context, o *ramaris.NotificationListOptions) iter.Seq2[ramaris.Notification, error] {
+			return c.StrategyNotifications(ctx, shareID, o)
+		},
+	)(ctx, c, out, args[1:])
+}
+
+func runGetWallet(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("wallets get requires a wallet ID: %w", errUsage)
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID %q: %w", args[0], errUsage)
+	}
+	w, err := c.GetWallet(ctx, id)
+	if err != nil {
+		return err
+	}
+	return writeOne(out, walletDetailColumns, w, walletDetailRow)
+}
+
+func runWalletSwaps(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("wallets swaps requires a wallet ID: %w", errUsage)
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID %q: %w", args[0], errUsage)
+	}
+
+	fs := newFlagSet("wallets swaps", out)
+	opts := &ramaris.SwapListOptions{}
+	fs.StringVar(&opts.Cursor, "cursor", "", "resume from this cursor")
+	fs.IntVar(&opts.Limit, "limit", 0, "swaps per page")
+	fs.Func("since", "only swaps at or after this RFC 3339 time", timeFlag(&opts.Since))
+	fs.Func("until", "only swaps at or before this RFC 3339 time", timeFlag(&opts.Until))
+	fetchAll := fs.Bool("all", false, "follow cursors to the oldest swap")
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("wallets swaps: unexpected argument %q: %w", fs.Arg(0), errUsage)
+	}
+
+	if !*fetchAll {
+		resp, err := c.ListWalletSwaps(ctx, id, opts)
+		if err != nil {
+			return err
+		}
+		if resp.Pagination.HasMore {
+			fmt.Fprintf(out.errw, "next cursor: %s\n", resp.Pagination.NextCursor)
+		}
+		return writeList(out, swapColumns, resp.Data, swapRow)
+	}
+
+	var swaps []ramaris.Swap
+	for sw, err := range c.WalletSwaps(ctx, id, opts) {
+		if err != nil {
+			return err
+		}
+		swaps = append(swaps, sw)
+	}
+	return writeList(out, swapColumns, swaps, swapRow)
+}
+
+func runWalletPositions(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("wallets positions requires a wallet ID: %w", errUsage)
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID %q: %w", args[0], errUsage)
+	}
+	positions, err := c.ListWalletPositions(ctx, id)
+	if err != nil {
+		return err
+	}
+	return writeList(out, positionColumns, positions, positionRow)
+}
+
+func runWatchlistAdd(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("watchlist add requires a share ID: %w", errUsage)
+	}
+	ws, err := c.AddToWatchlist(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return writeOne(out, watchlistColumns, ws, func(ws *ramaris.WatchlistStrategy) []string { return watchlistRow(*ws) })
+}
+
+func runWatchlistRemove(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("watchlist remove requires a share ID: %w", errUsage)
+	}
+	return c.RemoveFromWatchlist(ctx, args[0])
+}
+
+func runFollowWallet(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("wallets follow requires a wallet ID: %w", errUsage)
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID %q: %w", args[0], errUsage)
+	}
+	w, err := c.FollowWallet(ctx, id)
+	if err != nil {
+		return err
+	}
+	return writeOne(out, followedWalletColumns, w, func(w *ramaris.FollowedWallet) []string { return followedWalletRow(*w) })
+}
+
+func runUnfollowWallet(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("wallets unfollow requires a wallet ID: %w", errUsage)
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid wallet ID %q: %w", args[0], errUsage)
+	}
+	return c.UnfollowWallet(ctx, id)
+}
+
+func runWatch(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	fs := newFlagSet("watch", out)
+	var cfg ramaris.WatchConfig
+	fs.Func("strategy", "share ID to watch (repeatable)", func(v string) error {
+		cfg.Strategies = append(cfg.Strategies, v)
+		return nil
+	})
+	fs.Func("wallet", "wallet ID to watch (repeatable)", func(v string) error {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		cfg.Wallets = append(cfg.Wallets, id)
+		return nil
+	})
+	fs.DurationVar(&cfg.Interval, "interval", time.Minute, "time between polls")
+	fs.Float64Var(&cfg.MinROIChange, "min-roi-change", 0, "smallest ROI move to report, in percentage points")
+	fs.IntVar(&cfg.Reserve, "reserve", 0, "rate limit requests to leave for other clients")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("watch: unexpected argument %q: %w", fs.Arg(0), errUsage)
+	}
+	if len(cfg.Strategies) == 0 && len(cfg.Wallets) == 0 {
+		return fmt.Errorf("watch requires at least one -strategy or -wallet: %w", errUsage)
+	}
+
+	lw := newLiveWriter(out, changeColumns)
+	for ch := range c.Watch(ctx, cfg) {
+		if ch.Kind == ramaris.ChangeError {
+			fmt.Fprintf(out.errw, "ramaris: %s: %v\n", changeTarget(ch), ch.Err)
+			continue
+		}
+		if err := lw.write(ch, changeRow(ch)); err != nil {
+			return err
+		}
+	}
+	// The watch ends when ctx is done: an interrupt is a clean exit, -timeout is not.
+	if err := ctx.Err(); errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("watch stopped by -timeout: %w", err)
+	}
+	return nil
+}
+
+func runStream(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	fs := newFlagSet("stream", out)
+	var filters ramaris.StreamFilters
+	fs.Func("type", "event type to receive: swap, position or notification (repeatable)", func(v string) error {
+		filters.Types = append(filters.Types, ramaris.StreamEventType(v))
+		return nil
+	})
+	fs.Func("wallet", "followed wallet ID to receive events for (repeatable)", func(v string) error {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		filters.Wallets = append(filters.Wallets, id)
+		return nil
+	})
+	fs.Func("strategy", "watchlisted share ID to receive events for (repeatable)", func(v string) error {
+		filters.Strategies = append(filters.Strategies, v)
+		return nil
+	})
+	lastEventID := fs.String("last-event-id", "", "resume after this event ID")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("stream: unexpected argument %q: %w", fs.Arg(0), errUsage)
+	}
+
+	s, err := c.Stream(ctx, &filters, ramaris.WithLastEventID(*lastEventID))
+	if err != nil {
+		return err
+	}
+	lw := newLiveWriter(out, streamEventColumns)
+	for ev := range s.Events() {
+		if err := lw.write(ev, streamEventRow(ev)); err != nil {
+			return err
+		}
+	}
+	// Interrupting a stream is how it normally ends; -timeout is not.
+	switch err := s.Err(); {
+	case errors.Is(err, context.Canceled):
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("stream stopped by -timeout: %w", err)
+	default:
+		return err
+	}
+}
+
+func runProfile(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("profile takes no arguments: %w", errUsage)
+	}
+	p, err := c.GetProfile(ctx)
+	if err != nil {
+		return err
+	}
+	return writeOne(out, []string{"ID", "NICKNAME", "EMAIL", "FOUNDER", "STRATEGIES_CREATED", "WALLETS_FOLLOWED", "STRATEGIES_FOLLOWED"}, p, func(p *ramaris.UserProfile) []string {
+		return []string{p.ID, str(p.Nickname), p.Email, strconv.FormatBool(p.IsFounder),
+			strconv.Itoa(p.Stats.StrategiesCreated), strconv.Itoa(p.Stats.WalletsFollowed), strconv.Itoa(p.Stats.StrategiesFollowed)}
+	})
+}
+
+func runSubscription(ctx context.Context, c *ramaris.Client, out *output, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("subscription takes no arguments: %w", errUsage)
+	}
+	s, err := c.GetSubscription(ctx)
+	if err != nil {
+		return err
+	}
+	return writeOne(out, []string{"TIER", "STATUS", "PERIOD_END", "CANCEL_AT_PERIOD_END", "FOUNDER"}, s, func(s *ramaris.Subscription) []string {
+		return []string{s.Tier, s.Status, timestamp(s.CurrentPeriodEnd), strconv.FormatBool(s.CancelAtPeriodEnd), strconv.FormatBool(s.IsFounder)}
+	})
+}
+
+// --- Row formatting ---
+
+var strategyColumns = []string{"SHARE_ID", "NAME", "ROI", "WALLETS", "SWAPS", "CREATOR", "LAST_ACTIVITY"}
+
+func strategyRow(s ramaris.StrategyListItem) []string {
+	return []string{s.ShareID, s.Name, percent(s.ROIPercent), strconv.Itoa(s.Stats.WalletsTracked),
+		strconv.Itoa(s.Stats.TotalSwaps), s.Creator.Nickname, timestamp(s.LastActivityAt)}
+}
+
+var strategyDetailColumns = []string{"SHARE_ID", "NAME", "STATUS", "ROI", "WALLETS", "SWAPS", "NOTIFICATIONS", "TAGS"}
+
+func strategyDetailRow(s *ramaris.Strategy) []string {
+	return []string{s.ShareID, s.Name, s.Status, percent(s.ROIPercent), strconv.Itoa(s.Stats.WalletsTracked),
+		strconv.Itoa(s.Stats.TotalSwaps), strconv.Itoa(s.Stats.TotalNotifications), strings.Join(s.Tags, ",")}
+}
+
+var walletColumns = []string{"ID", "WIN_RATE", "REALIZED_PNL", "SWAPS", "OPEN_POSITIONS", "TAGS"}
+
+func walletRow(w ramaris.WalletListItem) []string {
+	return []string{strconv.Itoa(w.ID), number(w.WinRate), number(w.RealizedPnL), strconv.Itoa(w.Stats.TotalSwaps),
+		strconv.Itoa(w.Stats.OpenPositions), strings.Join(w.Tags, ",")}
+}
+
+var strategyWalletColumns = append(slices.Clip(walletColumns), "WEIGHT", "ADDED_AT")
+
+func strategyWalletRow(w ramaris.StrategyWallet) []string {
+	return append(walletRow(w.WalletListItem), strconv.FormatFloat(w.Weight, 'f', -1, 64), timestamp(&w.AddedAt))
+}
+
+var followedWalletColumns = append(slices.Clip(walletColumns), "FOLLOWED_AT")
+
+func followedWalletRow(w ramaris.FollowedWallet) []string {
+	return append(walletRow(w.WalletListItem), timestamp(&w.FollowedAt))
+}
+
+var walletDetailColumns = []string{"ID", "STATUS", "WIN_RATE", "REALIZED_PNL", "SWAPS", "OPEN_POSITIONS", "FOLLOWERS", "TAGS"}
+
+func walletDetailRow(w *ramaris.Wallet) []string {
+	return []string{strconv.Itoa(w.ID), w.Status, number(w.WinRate), number(w.RealizedPnL), strconv.Itoa(w.Stats.TotalSwaps),
+		strconv.Itoa(w.Stats.OpenPositions), strconv.Itoa(w.Stats.Followers), strings.Join(w.Tags, ",")}
+}
+
+var swapColumns = []string{"TIME", "TX_HASH", "BLOCK", "TOKEN_IN", "AMOUNT_IN", "TOKEN_OUT", "AMOUNT_OUT", "VALUE_USD", "REALIZED_PNL"}
+
+func swapRow(s ramaris.Swap) []string {
+	return []string{timestamp(&s.Timestamp), s.TxHash, strconv.FormatUint(s.BlockNumber, 10), s.TokenIn.Symbol, s.AmountIn.String(),
+		s.TokenOut.Symbol, s.AmountOut.String(), decimal(s.ValueUsd), decimal(s.RealizedPnL)}
+}
+
+var positionColumns = []string{"TOKEN", "SYMBOL", "SIZE", "AVG_ENTRY", "COST_BASIS_USD", "UNREALIZED_PNL", "OPENED_AT"}
+
+func positionRow(p ramaris.Position) []string {
+	return []string{p.TokenAddress, p.Symbol, p.Size.String(), p.AverageEntryPrice.String(), p.CostBasisUsd.String(),
+		p.UnrealizedPnL.String(), timestamp(&p.OpenedAt)}
+}
+
+var notificationColumns = []string{"ID", "TIME", "TYPE", "STRATEGY", "WALLET", "READ", "MESSAGE"}
+
+func notificationRow(n ramaris.Notification) []string {
+	wallet := ""
+	if n.WalletID != nil {
+		wallet = strconv.Itoa(*n.WalletID)
+	}
+	return []string{n.ID, timestamp(&n.CreatedAt), string(n.Type), n.StrategyShareID, wallet, strconv.FormatBool(n.Read), n.Message}
+}
+
+var changeColumns = []string{"TIME", "KIND", "TARGET", "DETAIL"}
+
+func changeRow(ch ramaris.Change) []string {
+	var detail string
+	switch ch.Kind {
+	case ramaris.ChangeROI:
+		detail = percent(ch.OldROI) + " -> " + percent(ch.NewROI)
+	case ramaris.ChangeStatus:
+		detail = ch.OldStatus + " -> " + ch.NewStatus
+	case ramaris.ChangeTags:
+		detail = "+" + strings.Join(ch.AddedTags, ",") + " -" + strings.Join(ch.RemovedTags, ",")
+	case ramaris.ChangeNewSwaps:
+		hashes := make([]string, len(ch.Swaps))
+		for i, sw := range ch.Swaps {
+			hashes[i] = sw.TxHash
+		}
+		detail = strings.Join(hashes, ",")
+	}
+	return []string{timestamp(&ch.DetectedAt), string(ch.Kind), changeTarget(ch), detail}
+}
+
+func changeTarget(ch ramaris.Change) string {
+	if ch.ShareID != "" {
+		return "strategy " + ch.ShareID
+	}
+	return "wallet " + strconv.Itoa(ch.WalletID)
+}
+
+var streamEventColumns = []string{"ID", "TYPE", "TARGET", "DETAIL"}
+
+func streamEventRow(ev ramaris.StreamEvent) []string {
+	target := "wallet " + strconv.Itoa(ev.WalletID)
+	if ev.StrategyShareID != "" {
+		target = "strategy " + ev.StrategyShareID
+	}
+	var detail string
+	switch {
+	case ev.Swap != nil:
+		detail = ev.Swap.TxHash + " " + ev.Swap.AmountIn.String() + " " + ev.Swap.TokenIn.Symbol + " -> " + ev.Swap.TokenOut.Symbol
+	case ev.Position != nil:
+		detail = ev.Position.Size.String() + " " + ev.Position.Symbol
+	case ev.Notification != nil:
+		detail = ev.Notification.Message
+	}
+	return []string{ev.ID, string(ev.Type), target, detail}
+}
+
+var watchlistColumns = []string{"SHARE_ID", "NAME", "ROI", "CREATOR", "COPIED_AT"}
+
+func watchlistRow(s ramaris.WatchlistStrategy) []string {
+	return []string{s.ShareID, s.Name, percent(s.ROIPercent), s.Creator.Nickname, timestamp(&s.CopiedAt)}
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func number(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func decimal(d *ramaris.Decimal) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
+
+func percent(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', 2, 64) + "%"
+}
+
+func timestamp(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,219 @@
+// Command ramaris is a command-line client for the Ramaris API.
+//
+// Usage:
+//
+//	ramaris [global flags] <command> [flags] [args]
+//
+// The API key is read from the RAMARIS_API_KEY environment variable or, if
+// unset, from the apiKey field of the JSON config file at
+// $XDG_CONFIG_HOME/ramaris/config.json (see -config).
+//
+// Exit codes:
+//
+//	0  success
+//	1  unexpected failure (e.g. malformed response)
+//	2  usage error, invalid request or missing API key
+//	3  API error (*ramaris.Error)
+//	4  rate limited (*ramaris.RateLimitError)
+//	5  transport failure (network error, timeout)
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	ramaris "github.com/ramaris-app/go-sdk"
+)
+
+const (
+	exitOK          = 0
+	exitFailure     = 1
+	exitUsage       = 2
+	exitAPIError    = 3
+	exitRateLimited = 4
+	exitTransport   = 5
+)
+
+// errUsage marks errors caused by invalid command-line input.
+var errUsage = errors.New("usage error")
+
+// config is the on-disk configuration file.
+type config struct {
+	APIKey  string `json:"apiKey"`
+	BaseURL string `json:"baseURL"`
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
+
+// run executes the CLI and returns its exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	fs := flag.NewFlagSet("ramaris", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", "", "path to JSON config file (default $XDG_CONFIG_HOME/ramaris/config.json)")
+	baseURL := fs.String("base-url", "", "API base URL (overrides config)")
+	format := fs.String("o", "table", "output format: table, json, csv, ndjson")
+	timeout := fs.Duration("timeout", 30*time.Second, "overall timeout, 0 for none; watch and stream run until interrupted unless it is set")
+	logLevel := fs.String("log-level", "", "log requests to stderr at this level: debug, info, warn or error")
+	fs.Usage = func() { usage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if !validFormat(*format) {
+		fmt.Fprintf(stderr, "ramaris: unknown output format %q\n", *format)
+		return exitUsage
+	}
+
+	var logger *slog.Logger
+	if *logLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+			fmt.Fprintf(stderr, "ramaris: unknown log level %q\n", *logLevel)
+			return exitUsage
+		}
+		logger = slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: level}))
+	}
+
+	cmd, cmdArgs := lookup(fs.Args())
+	if cmd == nil {
+		fs.Usage()
+		return exitUsage
+	}
+
+	cfg, err := loadConfig(*configPath, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "ramaris: %v\n", err)
+		return exitUsage
+	}
+	if *baseURL != "" {
+		cfg.BaseURL = *baseURL
+	}
+	if cfg.APIKey == "" {
+		fmt.Fprintln(stderr, "ramaris: no API key; set RAMARIS_API_KEY or apiKey in the config file")
+		return exitUsage
+	}
+
+	var opts []ramaris.Option
+	if cfg.BaseURL != "" {
+		opts = append(opts, ramaris.WithBaseURL(cfg.BaseURL))
+	}
+	if logger != nil {
+		opts = append(opts, ramaris.WithLogger(logger))
+	}
+	client := ramaris.NewClient(cfg.APIKey, opts...)
+
+	// Live commands run until interrupted; only an explicit -timeout ends them.
+	timeoutSet := false
+	fs.Visit(func(f *flag.Flag) { timeoutSet = timeoutSet || f.Name == "timeout" })
+	if *timeout > 0 && (!cmd.live() || timeoutSet) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	out := &output{w: stdout, errw: stderr, format: *format}
+	if err := cmd.run(ctx, client, out, cmdArgs); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		// A bare errUsage comes from flag parsing, which has already reported it.
+		if err != errUsage {
+			fmt.Fprintf(stderr, "ramaris: %v\n", err)
+		}
+		return exitCode(err)
+	}
+	return exitOK
+}
+
+// exitCode maps an error to the documented exit codes.
+func exitCode(err error) int {
+	var apiErr *ramaris.Error
+	var urlErr *url.Error
+	var vErr *ramaris.ValidationError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage), errors.As(err, &vErr):
+		return exitUsage
+	case errors.Is(err, ramaris.ErrRateLimited):
+		return exitRateLimited
+	case errors.As(err, &apiErr):
+		return exitAPIError
+	case errors.As(err, &urlErr), errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return exitTransport
+	default:
+		return exitFailure
+	}
+}
+
+// loadConfig reads the config file, if any, and applies environment overrides.
+func loadConfig(path string, getenv func(string) string) (config, error) {
+	var cfg config
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigPath(getenv)
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, os.ErrNotExist):
+			return cfg, fmt.Errorf("reading config file: %w", err)
+		}
+	}
+
+	if key := getenv("RAMARIS_API_KEY"); key != "" {
+		cfg.APIKey = key
+	}
+	if u := getenv("RAMARIS_BASE_URL"); u != "" {
+		cfg.BaseURL = u
+	}
+	return cfg, nil
+}
+
+func defaultConfigPath(getenv func(string) string) string {
+	dir := getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home := getenv("HOME")
+		if home == "" {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "ramaris", "config.json")
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: ramaris [global flags] <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-22s %s\n", c.name+" "+c.args, c.help)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Global flags:")
+	fs.PrintDefaults()
+}
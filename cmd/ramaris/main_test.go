@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ramaris "github.com/ramaris-app/go-sdk"
+	"github.com/ramaris-app/go-sdk/ramaristest"
+)
+
+func newFakeAPI(t *testing.T) *ramaristest.Server {
+	t.Helper()
+	srv := ramaristest.NewServer()
+	t.Cleanup(srv.Close)
+
+	roi := 12.5
+	for i, id := range []string{"s1", "s2", "s3"} {
+		srv.AddStrategy(ramaris.Strategy{
+			ID:         i + 1,
+			ShareID:    id,
+			Name:       "Strategy " + id,
+			ROIPercent: &roi,
+			CreatedAt:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Creator:    ramaris.StrategyCreator{Nickname: "alice"},
+			Status:     "ACTIVE",
+			Tags:       []string{"base", "defi"},
+		})
+	}
+	srv.AddWallet(ramaris.Wallet{ID: 456, Status: "ACTIVE", Tags: []string{"whale"}})
+	srv.AddStrategyWallets("s1", ramaris.StrategyWallet{WalletListItem: ramaris.WalletListItem{ID: 456}, Weight: 0.5})
+	for i := 1; i <= 3; i++ {
+		srv.AddSwaps(456, ramaris.Swap{
+			TxHash:      fmt.Sprintf("0x%d", i),
+			BlockNumber: uint64(i),
+			Timestamp:   time.Date(2025, 3, i, 0, 0, 0, 0, time.UTC),
+			TokenIn:     ramaris.SwapToken{Symbol: "WETH"},
+			AmountIn:    "0.5",
+		})
+	}
+	srv.AddNotifications(ramaris.Notification{
+		ID:              "n1",
+		Type:            ramaris.NotificationSwapDetected,
+		StrategyShareID: "s1",
+		CreatedAt:       time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	srv.SetProfile(ramaris.UserProfile{ID: "u1", Email: "a@b.com"})
+	srv.SetSubscription(ramaris.Subscription{Tier: "PRO", Status: "active"})
+	return srv
+}
+
+func runCLI(t *testing.T, srv *ramaristest.Server, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	return runCLIContext(t, context.Background(), srv, args...)
+}
+
+// runCLIContext is runCLI with ctx standing in for the interrupt signal.
+func runCLIContext(t *testing.T, ctx context.Context, srv *ramaristest.Server, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	env := map[string]string{
+		"RAMARIS_API_KEY":  ramaristest.DefaultAPIKey,
+		"RAMARIS_BASE_URL": srv.URL,
+		"XDG_CONFIG_HOME":  t.TempDir(),
+	}
+	var out, errOut bytes.Buffer
+	code = run(ctx, args, &out, &errOut, func(k string) string { return env[k] })
+	return code, out.String(), errOut.String()
+}
+
+func TestRun_Table(t *testing.T) {
+	srv := newFakeAPI(t)
+
+	code, out, errOut := runCLI(t, srv, "strategies", "list")
+	if code != exitOK {
+		t.Fatalf("exit = %d, stderr = %q", code, errOut)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want header + 3:\n%s", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "SHARE_ID") || !strings.Contains(lines[1], "12.50%") {
+		t.Errorf("unexpected table:\n%s", out)
+	}
+}
+
+func TestRun_Formats(t *testing.T) {
+	srv := newFakeAPI(t)
+
+	_, out, _ := runCLI(t, srv, "-o", "json", "strategies", "list", "-all", "-page-size", "2")
+	var items []ramaris.StrategyListItem
+	if err := json.Unmarshal([]byte(out), &items); err != nil {
+		t.Fatalf("json output: %v\n%s", err, out)
+	}
+	if len(items) != 3 {
+		t.Errorf("json items = %d, want 3", len(items))
+	}
+
+	_, out, _ = runCLI(t, srv, "-o", "json", "strategies", "list", "-min-roi", "50", "-tag", "defi")
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("filtered json = %q, want []", out)
+	}
+
+	_, out, _ = runCLI(t, srv, "-o", "ndjson", "strategies", "list")
+	if n := strings.Count(out, "\n"); n != 3 {
+		t.Errorf("ndjson lines = %d, want 3", n)
+	}
+
+	_, out, _ = runCLI(t, srv, "-o", "csv", "strategies", "get", "s1")
+	if !strings.Contains(out, `"base,defi"`) {
+		t.Errorf("csv output missing quoted tags:\n%s", out)
+	}
+
+	_, out, _ = runCLI(t, srv, "-o", "ndjson", "wallets", "swaps", "456", "-all", "-limit", "1")
+	if n := strings.Count(out, "\n"); n != 3 {
+		t.Errorf("swaps ndjson lines = %d, want 3", n)
+	}
+
+	_, out, _ = runCLI(t, srv, "-o", "json", "wallets", "get", "456")
+	var w ramaris.Wallet
+	if err := json.Unmarshal([]byte(out), &w); err != nil || w.ID != 456 {
+		t.Errorf("wallet json = %q, err = %v", out, err)
+	}
+}
+
+func TestRun_Commands(t *testing.T) {
+	srv := newFakeAPI(t)
+
+	for _, args := range [][]string{
+		{"health"},
+		{"wallets", "list"},
+		{"strategies", "wallets", "s1", "-all"},
+		{"wallets", "swaps", "456", "-limit", "2", "-since", "2025-03-01T00:00:00Z"},
+		{"wallets", "positions", "456"},
+		{"wallets", "follow", "456"},
+		{"wallets", "following", "-all"},
+		{"wallets", "unfollow", "456"},
+		{"strategies", "update", "s3", "-name", "Renamed", "-clear-tags", "-status", "PAUSED"},
+		{"strategies", "add-wallet", "s2", "456", "-weight", "0.5"},
+		{"strategies", "remove-wallet", "s2", "456"},
+		{"strategies", "create", "-name", "Fresh", "-tag", "base"},
+		{"strategies", "delete", "s3"},
+		{"watchlist", "add", "s2"},
+		{"watchlist", "-page", "1"},
+		{"watchlist", "remove", "s2"},
+		{"notifications", "-unread", "-type", "SWAP_DETECTED"},
+		{"strategies", "notifications", "s1", "-since", "2025-03-01T00:00:00Z", "-all"},
+		{"profile"},
+		{"subscription"},
+	} {
+		if code, _, errOut := runCLI(t, srv, args...); code != exitOK {
+			t.Errorf("%v: exit = %d, stderr = %q", args, code, errOut)
+		}
+	}
+}
+
+func TestRun_ExitCodes(t *testing.T) {
+	srv := newFakeAPI(t)
+
+	tests := []struct {
+		name  string
+		fault *ramaristest.Fault
+		args  []string
+		want  int
+	}{
+		{"unknown command", nil, []string{"nope"}, exitUsage},
+		{"bad flag", nil, []string{"strategies", "list", "-bogus"}, exitUsage},
+		{"bad wallet id", nil, []string{"wallets", "get", "abc"}, exitUsage},
+		{"missing swaps wallet", nil, []string{"wallets", "swaps", "-limit", "1"}, exitUsage},
+		{"stray argument", nil, []string{"strategies", "wallets", "s1", "extra"}, exitUsage},
+		{"stray swaps argument", nil, []string{"wallets", "swaps", "456", "extra"}, exitUsage},
+		{"stray update argument", nil, []string{"strategies", "update", "s1", "-name", "x", "extra"}, exitUsage},
+		{"stray add-wallet argument", nil, []string{"strategies", "add-wallet", "s1", "456", "extra"}, exitUsage},
+		{"stray watch argument", nil, []string{"-timeout", "1s", "watch", "-wallet", "456", "extra"}, exitUsage},
+		{"stray stream argument", nil, []string{"-timeout", "1s", "stream", "extra"}, exitUsage},
+		{"bad since", nil, []string{"wallets", "swaps", "456", "-since", "yesterday"}, exitUsage},
+		{"bad format", nil, []string{"-o", "xml", "health"}, exitUsage},
+		{"invalid request", nil, []string{"strategies", "create", "-status", "GONE"}, exitUsage},
+		{"not found", nil, []string{"strategies", "get", "missing"}, exitAPIError},
+		{"rate limited", &ramaristest.Fault{Status: 429, RetryAfter: 60, Times: 1}, []string{"health"}, exitRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fault != nil {
+				srv.InjectFault(*tt.fault)
+			}
+			if code, _, _ := runCLI(t, srv, tt.args...); code != tt.want {
+				t.Errorf("exit = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_LogLevel(t *testing.T) {
+	srv := newFakeAPI(t)
+
+	_, _, errOut := runCLI(t, srv, "-log-level", "debug", "health")
+	if !strings.Contains(errOut, `msg="ramaris: request"`) || !strings.Contains(errOut, "status=200") {
+		t.Errorf("stderr = %q, want a debug record per request", errOut)
+	}
+	if strings.Contains(errOut, ramaristest.DefaultAPIKey) {
+		t.Errorf("stderr leaks the API key: %q", errOut)
+	}
+
+	if _, _, errOut := runCLI(t, srv, "-log-level", "warn", "health"); errOut != "" {
+		t.Errorf("warn level stderr = %q, want nothing for a successful request", errOut)
+	}
+	if code, _, _ := runCLI(t, srv, "-log-level", "loud", "health"); code != exitUsage {
+		t.Errorf("bad log level: exit = %d, want %d", code, exitUsage)
+	}
+}
+
+func TestRun_TransportFailure(t *testing.T) {
+	srv := newFakeAPI(t)
+	url := srv.URL
+	srv.Close()
+
+	var out, errOut bytes.Buffer
+	env := map[string]string{"RAMARIS_API_KEY": "k", "RAMARIS_BASE_URL": url, "XDG_CONFIG_HOME": t.TempDir()}
+	code := run(context.Background(), []string{"health"}, &out, &errOut, func(k string) string { return env[k] })
+	if code != exitTransport {
+		t.Errorf("exit = %d, want %d (stderr %q)", code, exitTransport, errOut.String())
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ramaris", "config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"apiKey":"rms_file","baseURL":"http://file"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{"XDG_CONFIG_HOME": dir}
+	getenv := func(k string) string { return env[k] }
+
+	cfg, err := loadConfig("", getenv)
+	if err != nil {
+		t.Fatalf("loadConfig() error: %v", err)
+	}
+	if cfg.APIKey != "rms_file" || cfg.BaseURL != "http://file" {
+		t.Errorf("config = %+v, want values from file", cfg)
+	}
+
+	env["RAMARIS_API_KEY"] = "rms_env"
+	cfg, _ = loadConfig("", getenv)
+	if cfg.APIKey != "rms_env" {
+		t.Errorf("APIKey = %q, want env override", cfg.APIKey)
+	}
+
+	if _, err := loadConfig(filepath.Join(dir, "missing.json"), getenv); err == nil {
+		t.Error("loadConfig(explicit missing) error = nil, want error")
+	}
+}
+
+func TestRun_Watch(t *testing.T) {
+	srv := newFakeAPI(t)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		srv.AddStrategy(ramaris.Strategy{ShareID: "s1", Name: "Strategy s1", Status: "PAUSED", Tags: []string{"base", "defi"}})
+	}()
+
+	code, out, errOut := runCLIContext(t, interruptAfter(t, 500*time.Millisecond), srv, "-o", "ndjson", "watch", "-strategy", "s1", "-interval", "20ms")
+	if code != exitOK {
+		t.Fatalf("exit = %d, stderr = %q", code, errOut)
+	}
+	if !strings.Contains(out, `"kind":"STATUS","shareId":"s1"`) || !strings.Contains(out, `"newStatus":"PAUSED"`) {
+		t.Errorf("output = %q, want a status change", out)
+	}
+
+	code, _, errOut = runCLI(t, srv, "-timeout", "100ms", "watch", "-strategy", "s1", "-interval", "20ms")
+	if code != exitTransport || !strings.Contains(errOut, "watch stopped by -timeout") {
+		t.Errorf("watch with -timeout: exit = %d, stderr = %q; want %d", code, errOut, exitTransport)
+	}
+
+	if code, _, _ := runCLI(t, srv, "watch"); code != exitUsage {
+		t.Errorf("watch without targets: exit = %d, want %d", code, exitUsage)
+	}
+}
+
+func TestRun_Stream(t *testing.T) {
+	srv := newFakeAPI(t)
+	srv.Publish(
+		ramaris.StreamEvent{Type: ramaris.StreamSwap, WalletID: 456, Swap: &ramaris.Swap{TxHash: "0xabc", TokenIn: ramaris.SwapToken{Symbol: "WETH"}}},
+		ramaris.StreamEvent{Type: ramaris.StreamSwap, WalletID: 789},
+		ramaris.StreamEvent{Type: ramaris.StreamNotification, StrategyShareID: "s1", Notification: &ramaris.Notification{Message: "hello"}},
+	)
+
+	code, out, errOut := runCLIContext(t, interruptAfter(t, 200*time.Millisecond), srv, "stream", "-wallet", "456", "-strategy", "s1", "-last-event-id", "0")
+	if code != exitOK {
+		t.Fatalf("exit = %d, stderr = %q", code, errOut)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "0xabc") || !strings.Contains(lines[2], "strategy s1") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	_, out, _ = runCLIContext(t, interruptAfter(t, 200*time.Millisecond), srv, "-o", "ndjson", "stream", "-type", "notification")
+	if !strings.Contains(out, `"id":"3","type":"notification"`) || strings.Count(out, "\n") != 1 {
+		t.Errorf("ndjson output = %q, want the notification only", out)
+	}
+
+	code, _, errOut = runCLI(t, srv, "-timeout", "200ms", "stream")
+	if code != exitTransport || !strings.Contains(errOut, "stream stopped by -timeout") {
+		t.Errorf("stream with -timeout: exit = %d, stderr = %q; want %d", code, errOut, exitTransport)
+	}
+}
+
+// interruptAfter returns a context canceled after d, as by Ctrl-C.
+func interruptAfter(t *testing.T, d time.Duration) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	timer := time.AfterFunc(d, cancel)
+	t.Cleanup(func() {
+		timer.Stop()
+		cancel()
+	})
+	return ctx
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// output writes command results in the selected format.
+type output struct {
+	w      io.Writer
+	errw   io.Writer
+	format string
+}
+
+func validFormat(f string) bool {
+	switch f {
+	case "table", "json", "csv", "ndjson":
+		return true
+	}
+	return false
+}
+
+// writeList writes items as rows, or as a JSON array / one JSON object per line.
+func writeList[T any](out *output, columns []string, items []T, row func(T) []string) error {
+	switch out.format {
+	case "json":
+		if items == nil {
+			items = []T{}
+		}
+		return writeJSON(out.w, items)
+	case "ndjson":
+		enc := json.NewEncoder(out.w)
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = row(item)
+	}
+	return writeRows(out, columns, rows)
+}
+
+// writeOne writes a single resource.
+func writeOne[T any](out *output, columns []string, v T, row func(T) []string) error {
+	switch out.format {
+	case "json":
+		return writeJSON(out.w, v)
+	case "ndjson":
+		return json.NewEncoder(out.w).Encode(v)
+	}
+	return writeRows(out, columns, [][]string{row(v)})
+}
+
+func writeRows(out *output, columns []string, rows [][]string) error {
+	if out.format == "csv" {
+		cw := csv.NewWriter(out.w)
+		cw.Write(columns)
+		cw.WriteAll(rows)
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(out.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, r := range rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// liveWriter writes records as they arrive, for commands that run until
+// interrupted: one JSON object per line for the JSON formats, otherwise a
+// header followed by a flushed row per record.
+type liveWriter struct {
+	out *output
+	cw  *csv.Writer
+	enc *json.Encoder
+}
+
+func newLiveWriter(out *output, columns []string) *liveWriter {
+	lw := &liveWriter{out: out, enc: json.NewEncoder(out.w)}
+	switch out.format {
+	case "csv":
+		lw.cw = csv.NewWriter(out.w)
+		lw.cw.Write(columns)
+		lw.cw.Flush()
+	case "table":
+		fmt.Fprintln(out.w, strings.Join(columns, "\t"))
+	}
+	return lw
+}
+
+// write writes v, or row for the tabular formats.
+func (lw *liveWriter) write(v any, row []string) error {
+	switch lw.out.format {
+	case "json", "ndjson":
+		return lw.enc.Encode(v)
+	case "csv":
+		lw.cw.Write(row)
+		lw.cw.Flush()
+		return lw.cw.Error()
+	}
+	_, err := fmt.Fprintln(lw.out.w, strings.Join(row, "\t"))
+	return err
+}
@@ -0,0 +1,104 @@
+package ramaris
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// WithCoalescing deduplicates concurrent identical GET requests: while a
+// request for a URL is in flight, other callers asking for the same URL wait
+// for its result instead of issuing their own. Each caller still returns as
+// soon as its own context is done; the shared request is canceled only when
+// every waiting caller has given up.
+func WithCoalescing() Option {
+	return func(c *Client) { c.flights = &flightGroup{} }
+}
+
+// flightGroup tracks in-flight requests by URL.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a single shared request and its buffered result.
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+// do runs fn once per key among concurrent callers and gives each caller its
+// own copy of the response.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (*http.Response, error)) (*http.Response, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	f, ok := g.calls[key]
+	if !ok {
+		// The shared request keeps the first caller's values but not its
+		// cancellation, which is tracked through the waiter count instead.
+		fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+		go g.run(fctx, key, f, fn)
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		if f.err != nil {
+			return nil, f.err
+		}
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", f.status, http.StatusText(f.status)),
+			StatusCode: f.status,
+			Header:     f.header.Clone(),
+			Body:       io.NopCloser(bytes.NewReader(f.body)),
+		}, nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, f *flight, fn func(context.Context) (*http.Response, error)) {
+	defer f.cancel()
+
+	resp, err := fn(ctx)
+	if err == nil {
+		f.status = resp.StatusCode
+		f.header = resp.Header
+		f.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			err = fmt.Errorf("ramaris: failed to read response: %w", err)
+		}
+	}
+	f.err = err
+
+	g.mu.Lock()
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	close(f.done)
+}
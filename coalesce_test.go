@@ -0,0 +1,125 @@
+package ramaris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const coalescedWalletJSON = `{"data":{"id":456,"createdAt":"2025-01-01T00:00:00Z","stats":{},"status":"ACTIVE"}}`
+
+func TestClient_CoalescesConcurrentGets(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, coalescedWalletJSON)
+	})
+	WithCoalescing()(c)
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w, err := c.GetWallet(context.Background(), 456)
+			if err == nil && w.ID != 456 {
+				err = fmt.Errorf("ID = %d, want 456", w.ID)
+			}
+			errs <- err
+		}()
+	}
+
+	// Let every goroutine join the in-flight request before it completes.
+	for c.flightWaiters("/wallets/456") < n {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetWallet() error: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestClient_CoalescingCallerCancellation(t *testing.T) {
+	release := make(chan struct{})
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, coalescedWalletJSON)
+	})
+	WithCoalescing()(c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := c.GetWallet(ctx, 456)
+		canceled <- err
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.GetWallet(context.Background(), 456)
+		done <- err
+	}()
+
+	for c.flightWaiters("/wallets/456") < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled caller error = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("remaining caller error: %v", err)
+	}
+}
+
+func TestClient_CoalescingAllCallersCancel(t *testing.T) {
+	serverCanceled := make(chan struct{})
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(serverCanceled)
+	})
+	WithCoalescing()(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetWallet(ctx, 456); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetWallet() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	select {
+	case <-serverCanceled:
+	case <-time.After(time.Second):
+		t.Error("shared request was not canceled after every caller left")
+	}
+}
+
+// flightWaiters returns the number of callers waiting on the in-flight request for path.
+func (c *Client) flightWaiters(path string) int {
+	c.flights.mu.Lock()
+	defer c.flights.mu.Unlock()
+	if f, ok := c.flights.calls[c.buildURL(path, nil)]; ok {
+		return f.waiters
+	}
+	return 0
+}
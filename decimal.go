@@ -0,0 +1,60 @@
+package ramaris
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Decimal is an exact decimal number, such as a token amount, kept in its
+// textual form so that no precision is lost to float64 rounding. The API may
+// encode it as a JSON string or number; it is always marshaled as a string.
+type Decimal string
+
+// String returns the decimal text.
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// Float64 converts d to the nearest float64.
+func (d Decimal) Float64() (float64, error) {
+	return strconv.ParseFloat(string(d), 64)
+}
+
+// Rat converts d to an exact rational number.
+func (d Decimal) Rat() (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(string(d))
+	if !ok {
+		return nil, fmt.Errorf("ramaris: invalid decimal %q", string(d))
+	}
+	return r, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*d = Decimal(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("ramaris: invalid decimal %s", data)
+	}
+	*d = Decimal(n)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(d))
+}
@@ -0,0 +1,59 @@
+package ramaris
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Decimal
+	}{
+		{`"123456789012345678901234.000000000000000001"`, "123456789012345678901234.000000000000000001"},
+		{`1.5`, "1.5"},
+		{`1e-18`, "1e-18"},
+		{`null`, ""},
+	}
+
+	for _, tt := range tests {
+		var d Decimal
+		if err := json.Unmarshal([]byte(tt.raw), &d); err != nil {
+			t.Fatalf("Unmarshal(%s) error: %v", tt.raw, err)
+		}
+		if d != tt.want {
+			t.Errorf("Unmarshal(%s) = %q, want %q", tt.raw, d, tt.want)
+		}
+	}
+
+	var d Decimal
+	if err := json.Unmarshal([]byte(`true`), &d); err == nil {
+		t.Error("Unmarshal(true) error = nil, want error")
+	}
+}
+
+func TestDecimal_Conversions(t *testing.T) {
+	d := Decimal("0.1")
+
+	f, err := d.Float64()
+	if err != nil || f != 0.1 {
+		t.Errorf("Float64() = %v, %v, want 0.1", f, err)
+	}
+
+	r, err := d.Rat()
+	if err != nil {
+		t.Fatalf("Rat() error: %v", err)
+	}
+	if r.String() != "1/10" {
+		t.Errorf("Rat() = %s, want 1/10", r)
+	}
+
+	if _, err := Decimal("abc").Rat(); err == nil {
+		t.Error("Rat() on invalid decimal error = nil, want error")
+	}
+
+	out, _ := json.Marshal(Decimal("42.00"))
+	if string(out) != `"42.00"` {
+		t.Errorf("Marshal() = %s, want %q", out, `"42.00"`)
+	}
+}
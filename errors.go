@@ -1,16 +1,79 @@
 package ramaris
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for the kinds of failure callers usually handle, matched
+// with errors.Is:
+//
+//	if errors.Is(err, ramaris.ErrNotFound) { ... }
+//
+// An *Error matches by its code or, for codes the SDK doesn't know, its HTTP
+// status. A *RateLimitError matches ErrRateLimited, and a *ValidationError
+// matches ErrValidation. Use errors.As to get at the details.
+var (
+	ErrNotFound     = errors.New("ramaris: not found")
+	ErrUnauthorized = errors.New("ramaris: unauthorized")
+	ErrForbidden    = errors.New("ramaris: forbidden")
+	ErrValidation   = errors.New("ramaris: invalid request")
+	ErrServer       = errors.New("ramaris: server error")
+	ErrRateLimited  = errors.New("ramaris: rate limited")
+)
 
 // Error represents an API error response from Ramaris.
 type Error struct {
 	Code       string `json:"code"`
 	Message    string `json:"message"`
 	StatusCode int    `json:"-"`
+	// Fields lists the problems with individual request fields, if the API
+	// reported any. It is usually set for VALIDATION_ERROR.
+	Fields []FieldError `json:"details,omitempty"`
 }
 
 func (e *Error) Error() string {
-	return fmt.Sprintf("ramaris: %s: %s", e.Code, e.Message)
+	msg := fmt.Sprintf("ramaris: %s: %s", e.Code, e.Message)
+	if len(e.Fields) > 0 {
+		msg += " (" + joinFields(e.Fields) + ")"
+	}
+	return msg
+}
+
+// Is reports whether e is of the kind of target, one of the sentinel errors.
+func (e *Error) Is(target error) bool {
+	switch e.Code {
+	case "NOT_FOUND":
+		return target == ErrNotFound
+	case "UNAUTHORIZED":
+		return target == ErrUnauthorized
+	case "FORBIDDEN":
+		return target == ErrForbidden
+	case "VALIDATION_ERROR":
+		return target == ErrValidation
+	case "RATE_LIMITED":
+		return target == ErrRateLimited
+	case "INTERNAL_ERROR":
+		return target == ErrServer
+	}
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return target == ErrNotFound
+	case e.StatusCode == http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return target == ErrForbidden
+	case e.StatusCode == http.StatusBadRequest, e.StatusCode == http.StatusUnprocessableEntity:
+		return target == ErrValidation
+	case e.StatusCode == http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	case e.StatusCode >= 500:
+		return target == ErrServer
+	}
+	return false
 }
 
 // RateLimitError is returned when the API rate limit is exceeded (HTTP 429).
@@ -24,3 +87,67 @@ type RateLimitError struct {
 func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("ramaris: %s: %s (retry after %ds)", e.Code, e.Message, e.RetryAfter)
 }
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// parseError builds the error for a non-2xx response from its status code and
+// body: a *RateLimitError for HTTP 429 and an *Error otherwise.
+func parseError(statusCode int, body []byte) error {
+	var errResp errorResponse
+	_ = json.Unmarshal(body, &errResp)
+
+	if statusCode == http.StatusTooManyRequests {
+		return &RateLimitError{
+			Code:       codeOrDefault(errResp.Error.Code, "RATE_LIMITED"),
+			Message:    msgOrDefault(errResp.Error.Message, "rate limit exceeded"),
+			StatusCode: statusCode,
+			RetryAfter: errResp.Error.RetryAfter,
+		}
+	}
+	return &Error{
+		Code:       codeOrDefault(errResp.Error.Code, "UNKNOWN_ERROR"),
+		Message:    msgOrDefault(errResp.Error.Message, fmt.Sprintf("HTTP %d", statusCode)),
+		StatusCode: statusCode,
+		Fields:     errResp.Error.Details,
+	}
+}
+
+// FieldError describes a problem with a single request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError is returned when a request fails client-side validation.
+// Nothing is sent to the API.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return "ramaris: invalid request: " + joinFields(e.Fields)
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// joinFields formats fields as a single line.
+func joinFields(fields []FieldError) string {
+	msgs := make([]string, len(fields))
+	for i, f := range fields {
+		msgs[i] = f.String()
+	}
+	return strings.Join(msgs, "; ")
+}
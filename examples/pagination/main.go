@@ -20,24 +20,15 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	page := 1
 	total := 0
 
-	for {
-		resp, err := client.ListStrategies(ctx, &ramaris.ListOptions{Page: page, PageSize: 50})
+	// Pages are fetched lazily as the loop advances
+	for s, err := range client.Strategies(ctx, &ramaris.ListOptions{PageSize: 50}) {
 		if err != nil {
-			log.Fatalf("List strategies page %d failed: %v", page, err)
+			log.Fatalf("List strategies failed after %d items: %v", total, err)
 		}
-
-		for _, s := range resp.Data {
-			total++
-			fmt.Printf("%d. %s (%s)\n", total, s.Name, s.ShareID)
-		}
-
-		if page >= resp.Pagination.TotalPages {
-			break
-		}
-		page++
+		total++
+		fmt.Printf("%d. %s (%s)\n", total, s.Name, s.ShareID)
 	}
 
 	fmt.Printf("\nTotal strategies: %d\n", total)
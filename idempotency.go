@@ -0,0 +1,48 @@
+package ramaris
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContext struct{}
+
+// WithIdempotencyKey returns a copy of ctx that makes mutating requests
+// (POST, PUT, PATCH, DELETE) carry key as their Idempotency-Key header instead
+// of a generated one. The server applies a change at most once per key, so
+// reuse the key when repeating a call yourself, for example after a timeout.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContext{}, key)
+}
+
+// WithoutIdempotencyKeys stops the client from generating Idempotency-Key
+// headers. Mutating requests then carry a key only if one was set with
+// WithIdempotencyKey, and requests with non-idempotent methods (POST, PATCH)
+// without a key are never retried.
+func WithoutIdempotencyKeys() Option {
+	return func(c *Client) { c.noAutoKeys = true }
+}
+
+// idempotencyKey returns the key for a mutating request made with ctx: the
+// caller's key if set, otherwise a new random key unless generation is off.
+func (c *Client) idempotencyKey(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKeyContext{}).(string); ok && key != "" {
+		return key
+	}
+	if c.noAutoKeys {
+		return ""
+	}
+	return newIdempotencyKey()
+}
+
+// newIdempotencyKey returns a random (version 4) UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+	rand.Read(b[:]) // never fails on supported platforms
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,90 @@
+package ramaris
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestClient_IdempotencyKeyReusedAcrossRetries(t *testing.T) {
+	var keys []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"shareId":"abc"}}`))
+	})
+	WithRetryPolicy(ConstantBackoff{MaxRetries: 2, Interval: time.Millisecond})(c)
+
+	if _, err := c.AddToWatchlist(context.Background(), "abc"); err != nil {
+		t.Fatalf("AddToWatchlist() error: %v", err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("calls = %d, want 3", len(keys))
+	}
+	if !uuidPattern.MatchString(keys[0]) {
+		t.Errorf("Idempotency-Key = %q, want a UUID", keys[0])
+	}
+	if keys[1] != keys[0] || keys[2] != keys[0] {
+		t.Errorf("keys = %q, want the same key on every attempt", keys)
+	}
+}
+
+func TestClient_IdempotencyKeyPerCall(t *testing.T) {
+	var keys []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"status":"ok"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ctx := context.Background()
+
+	for range 2 {
+		if err := c.RemoveFromWatchlist(ctx, "abc"); err != nil {
+			t.Fatalf("RemoveFromWatchlist() error: %v", err)
+		}
+	}
+	if _, err := c.Health(ctx); err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+
+	if keys[0] == "" || keys[0] == keys[1] {
+		t.Errorf("DELETE keys = %q, want a distinct key per call", keys[:2])
+	}
+	if keys[2] != "" {
+		t.Errorf("GET Idempotency-Key = %q, want none", keys[2])
+	}
+}
+
+func TestClient_IdempotencyKeyFromContext(t *testing.T) {
+	var keys []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"shareId":"abc"}}`))
+	})
+	WithRetryPolicy(ConstantBackoff{MaxRetries: 1, Interval: time.Millisecond})(c)
+	WithoutIdempotencyKeys()(c)
+
+	ctx := WithIdempotencyKey(context.Background(), "order-42")
+	if _, err := c.AddToWatchlist(ctx, "abc"); err != nil {
+		t.Fatalf("AddToWatchlist() error: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "order-42" || keys[1] != "order-42" {
+		t.Errorf("keys = %q, want [order-42 order-42]", keys)
+	}
+}
@@ -0,0 +1,166 @@
+package ramaris
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Instrumenter observes API calls, for tracing and metrics. A call is one
+// Client method invocation and spans all of its attempts; calls served from
+// the cache are included.
+//
+// Implementations are called concurrently and must not block. To integrate
+// with a tracing library, start a span in StartCall, store it in the returned
+// context, and end it in EndCall.
+type Instrumenter interface {
+	// StartCall is called before the first attempt. The returned context is
+	// used for the call's requests and passed to EndCall.
+	StartCall(ctx context.Context, info CallInfo) context.Context
+
+	// EndCall is called once the call has finished, with the result fields
+	// of info set. It runs when the response arrives, before its body is
+	// read.
+	EndCall(ctx context.Context, info CallInfo)
+}
+
+// WithInstrumenter reports every API call to in.
+func WithInstrumenter(in Instrumenter) Option {
+	return func(c *Client) { c.instrumenter = in }
+}
+
+// CallInfo describes an API call. Operation, Method and Path are set for
+// StartCall; the remaining fields are set for EndCall.
+type CallInfo struct {
+	// Operation is the name of the Client method making the call, such as
+	// "GetWallet". FilterStrategies and FilterWallets are reported as
+	// "ListStrategies" and "ListWallets", which call the same endpoints.
+	Operation string
+	// Method is the HTTP method.
+	Method string
+	// Path is the path template relative to the API base URL, such as
+	// "/wallets/{id}", so that calls can be grouped without high
+	// cardinality.
+	Path string
+
+	// StatusCode is the HTTP status of the final response, or 0 if none was
+	// received.
+	StatusCode int
+	// Retries is the number of attempts after the first, including retries
+	// after rate limiting. Calls coalesced by WithCoalescing report 0.
+	Retries int
+	// RateLimit is the rate limit reported by the last response, or nil if
+	// it carried none.
+	RateLimit *RateLimitInfo
+	// Duration is the time from StartCall to EndCall.
+	Duration time.Duration
+	// Err is the error the call returned, if any.
+	Err error
+}
+
+// startCall reports call to the instrumenter and returns the context to use
+// for it, along with a function that reports its result.
+func (c *Client) startCall(ctx context.Context, call *apiCall, path string) (context.Context, func(*http.Response, error)) {
+	info := CallInfo{Method: call.method}
+	info.Operation, info.Path = lookupRoute(call.method, path)
+	ctx = c.instrumenter.StartCall(ctx, info)
+	start := time.Now()
+
+	return ctx, func(resp *http.Response, err error) {
+		info.Duration = time.Since(start)
+		info.Retries = max(call.attempts-1, 0)
+		info.RateLimit = call.rateLimit
+		info.Err = err
+		if resp != nil {
+			info.StatusCode = resp.StatusCode
+			if rl := parseRateLimit(resp.Header); rl != nil {
+				info.RateLimit = rl
+			}
+		} else {
+			info.StatusCode = errorStatus(err)
+		}
+		c.instrumenter.EndCall(ctx, info)
+	}
+}
+
+// errorStatus returns the HTTP status carried by an API error, or 0.
+func errorStatus(err error) int {
+	var rlErr *RateLimitError
+	var apiErr *Error
+	switch {
+	case errors.As(err, &rlErr):
+		return rlErr.StatusCode
+	case errors.As(err, &apiErr):
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// route maps an API path template to the Client method that calls it.
+type route struct {
+	method    string
+	path      string
+	operation string
+}
+
+// routes lists every endpoint the client calls. Literal segments must come
+// before parameters they could be mistaken for.
+var routes = []route{
+	{http.MethodGet, "/health", "Health"},
+	{http.MethodGet, "/strategies", "ListStrategies"},
+	{http.MethodPost, "/strategies", "CreateStrategy"},
+	{http.MethodGet, "/strategies/me/watchlist", "ListWatchlist"},
+	{http.MethodPost, "/strategies/me/watchlist", "AddToWatchlist"},
+	{http.MethodDelete, "/strategies/me/watchlist/{shareID}", "RemoveFromWatchlist"},
+	{http.MethodGet, "/strategies/{shareID}", "GetStrategy"},
+	{http.MethodPatch, "/strategies/{shareID}", "UpdateStrategy"},
+	{http.MethodDelete, "/strategies/{shareID}", "DeleteStrategy"},
+	{http.MethodGet, "/strategies/{shareID}/wallets", "ListStrategyWallets"},
+	{http.MethodPost, "/strategies/{shareID}/wallets", "AddWalletToStrategy"},
+	{http.MethodDelete, "/strategies/{shareID}/wallets/{walletID}", "RemoveWalletFromStrategy"},
+	{http.MethodGet, "/strategies/{shareID}/notifications", "ListStrategyNotifications"},
+	{http.MethodGet, "/wallets", "ListWallets"},
+	{http.MethodGet, "/wallets/{id}", "GetWallet"},
+	{http.MethodGet, "/wallets/{id}/swaps", "ListWalletSwaps"},
+	{http.MethodGet, "/wallets/{id}/positions", "ListWalletPositions"},
+	{http.MethodPost, "/wallets/{id}/follow", "FollowWallet"},
+	{http.MethodDelete, "/wallets/{id}/follow", "UnfollowWallet"},
+	{http.MethodGet, "/me/followed-wallets", "ListFollowedWallets"},
+	{http.MethodGet, "/me/notifications", "ListNotifications"},
+	{http.MethodGet, "/me/profile", "GetProfile"},
+	{http.MethodGet, "/me/subscription", "GetSubscription"},
+	{http.MethodGet, "/me/stream", "Stream"},
+}
+
+// lookupRoute returns the operation name and path template for a request.
+// Unknown paths are returned unchanged with an empty operation.
+func lookupRoute(method, path string) (operation, template string) {
+	segments := strings.Split(path, "/")
+	for _, r := range routes {
+		if r.method == method && matchRoute(r.path, segments) {
+			return r.operation, r.path
+		}
+	}
+	return "", path
+}
+
+func matchRoute(template string, segments []string) bool {
+	parts := strings.Split(template, "/")
+	if len(parts) != len(segments) {
+		return false
+	}
+	for i, p := range parts {
+		if strings.HasPrefix(p, "{") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
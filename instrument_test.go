@@ -0,0 +1,153 @@
+package ramaris
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type spanKey struct{}
+
+// recorder is an Instrumenter that records finished calls.
+type recorder struct {
+	mu    sync.Mutex
+	calls []CallInfo
+}
+
+func (r *recorder) StartCall(ctx context.Context, info CallInfo) context.Context {
+	return context.WithValue(ctx, spanKey{}, info.Operation)
+}
+
+func (r *recorder) EndCall(ctx context.Context, info CallInfo) {
+	if got := ctx.Value(spanKey{}); got != info.Operation {
+		panic(fmt.Sprintf("EndCall context carries %v, want the one from StartCall", got))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, info)
+}
+
+func (r *recorder) last(t *testing.T) CallInfo {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.calls) == 0 {
+		t.Fatal("no calls recorded")
+	}
+	return r.calls[len(r.calls)-1]
+}
+
+func TestInstrumenter(t *testing.T) {
+	var walletRequests atomic.Int32
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		switch r.URL.Path {
+		case "/wallets/456":
+			if walletRequests.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"id":456}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"NOT_FOUND","message":"strategy not found"}}`)
+		}
+	})
+	WithRetryPolicy(ConstantBackoff{MaxRetries: 2})(c)
+	rec := &recorder{}
+	WithInstrumenter(rec)(c)
+
+	if _, err := c.GetWallet(context.Background(), 456); err != nil {
+		t.Fatalf("GetWallet() error: %v", err)
+	}
+	info := rec.last(t)
+	if info.Operation != "GetWallet" || info.Method != http.MethodGet || info.Path != "/wallets/{id}" {
+		t.Errorf("call = %s %s %s, want GetWallet GET /wallets/{id}", info.Operation, info.Method, info.Path)
+	}
+	if info.StatusCode != http.StatusOK || info.Retries != 1 || info.Err != nil || info.Duration <= 0 {
+		t.Errorf("result = status %d, retries %d, err %v, duration %v; want 200 after 1 retry", info.StatusCode, info.Retries, info.Err, info.Duration)
+	}
+	if info.RateLimit == nil || info.RateLimit.Remaining != 42 {
+		t.Errorf("RateLimit = %+v, want 42 remaining", info.RateLimit)
+	}
+
+	if err := c.DeleteStrategy(context.Background(), "abc"); err == nil {
+		t.Fatal("DeleteStrategy() error = nil, want 404")
+	}
+	info = rec.last(t)
+	if info.Operation != "DeleteStrategy" || info.Path != "/strategies/{shareID}" || info.StatusCode != http.StatusNotFound || info.Err == nil {
+		t.Errorf("failed call = %+v, want DeleteStrategy 404 with error", info)
+	}
+	if info.RateLimit == nil || info.Retries != 0 {
+		t.Errorf("failed call RateLimit = %v, Retries = %d; want headers recorded and no retries", info.RateLimit, info.Retries)
+	}
+}
+
+func TestLookupRoute(t *testing.T) {
+	tests := []struct {
+		method, path      string
+		wantOp, wantRoute string
+	}{
+		{"GET", "/strategies/me/watchlist", "ListWatchlist", "/strategies/me/watchlist"},
+		{"DELETE", "/strategies/me/watchlist/abc", "RemoveFromWatchlist", "/strategies/me/watchlist/{shareID}"},
+		{"GET", "/strategies/abc", "GetStrategy", "/strategies/{shareID}"},
+		{"DELETE", "/strategies/abc/wallets/456", "RemoveWalletFromStrategy", "/strategies/{shareID}/wallets/{walletID}"},
+		{"GET", "/wallets/456/swaps", "ListWalletSwaps", "/wallets/{id}/swaps"},
+		{"POST", "/wallets/456/follow", "FollowWallet", "/wallets/{id}/follow"},
+		{"GET", "/unknown/1", "", "/unknown/1"},
+		{"GET", "/strategies/", "", "/strategies/"},
+	}
+	for _, tt := range tests {
+		op, route := lookupRoute(tt.method, tt.path)
+		if op != tt.wantOp || route != tt.wantRoute {
+			t.Errorf("lookupRoute(%s, %s) = %q, %q; want %q, %q", tt.method, tt.path, op, route, tt.wantOp, tt.wantRoute)
+		}
+	}
+
+	client := reflect.TypeOf(&Client{})
+	for _, r := range routes {
+		if _, ok := client.MethodByName(r.operation); !ok {
+			t.Errorf("route %s %s: Client has no method %s", r.method, r.path, r.operation)
+		}
+	}
+}
+
+func TestInstrumenter_Coalescing(t *testing.T) {
+	release := make(chan struct{})
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprint(w, `{"data":{"id":456}}`)
+	})
+	WithCoalescing()(c)
+	rec := &recorder{}
+	WithInstrumenter(rec)(c)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetWallet(context.Background(), 456); err != nil {
+				t.Errorf("GetWallet() error: %v", err)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	// Every caller gets its own call, whether or not it shared a request.
+	if len(rec.calls) != 3 {
+		t.Fatalf("recorded %d calls, want 3", len(rec.calls))
+	}
+	for _, info := range rec.calls {
+		if info.Operation != "GetWallet" || info.StatusCode != http.StatusOK {
+			t.Errorf("call = %+v, want GetWallet 200", info)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package ramaris
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WithLogger logs request activity to l. Each request attempt is logged at
+// debug level with its method, URL, status, duration and rate limit headers;
+// attempts that are retried or rate limited are logged at warn level instead,
+// with the delay before the next attempt. Stream reconnects are logged at
+// warn level too. The handler's level therefore sets the verbosity. The API
+// key is never logged.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) { c.logger = l }
+}
+
+// attemptLog is a finished request attempt to be logged.
+type attemptLog struct {
+	req     *http.Request
+	attempt int
+	resp    *http.Response
+	err     error
+	elapsed time.Duration
+	// retry reports whether another attempt follows after delay.
+	retry bool
+	delay time.Duration
+	// rateLimited is set for HTTP 429 responses.
+	rateLimited *RateLimitError
+}
+
+// logAttempt logs a at debug level, or at warn level if it is retried or was
+// rate limited.
+func (c *Client) logAttempt(a attemptLog) {
+	if c.logger == nil {
+		return
+	}
+	ctx := a.req.Context()
+	level, msg := slog.LevelDebug, "ramaris: request"
+	switch {
+	case a.rateLimited != nil:
+		level, msg = slog.LevelWarn, "ramaris: rate limited"
+	case a.retry:
+		level, msg = slog.LevelWarn, "ramaris: retrying request"
+	}
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", a.req.Method),
+		slog.String("url", c.redactURL(a.req.URL)),
+		slog.Int("attempt", a.attempt),
+		slog.Duration("duration", a.elapsed),
+	}
+	if a.resp != nil {
+		attrs = append(attrs, slog.Int("status", a.resp.StatusCode))
+		if rl := parseRateLimit(a.resp.Header); rl != nil {
+			attrs = append(attrs, slog.Group("rate_limit",
+				slog.Int("limit", rl.Limit),
+				slog.Int("remaining", rl.Remaining),
+				slog.Int("reset", rl.Reset),
+			))
+		}
+	}
+	if a.err != nil {
+		attrs = append(attrs, slog.String("error", c.redact(a.err.Error())))
+	}
+	if a.rateLimited != nil {
+		attrs = append(attrs, slog.Int("retry_after", a.rateLimited.RetryAfter))
+	}
+	if a.retry {
+		attrs = append(attrs, slog.Duration("retry_delay", a.delay))
+	}
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logReconnect logs that a stream connection failed with err and is reopened
+// after delay.
+func (c *Client) logReconnect(ctx context.Context, streamURL string, err error, delay time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	if u, err := url.Parse(streamURL); err == nil {
+		streamURL = u.Redacted()
+	}
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "ramaris: reconnecting stream",
+		slog.String("url", c.redact(streamURL)),
+		slog.String("error", c.redact(err.Error())),
+		slog.Duration("retry_delay", delay),
+	)
+}
+
+// redactURL returns u without any password or API key.
+func (c *Client) redactURL(u *url.URL) string {
+	return c.redact(u.Redacted())
+}
+
+// redact replaces the API key, raw or query-escaped, in s.
+func (c *Client) redact(s string) string {
+	if c.apiKey == "" {
+		return s
+	}
+	s = strings.ReplaceAll(s, c.apiKey, "REDACTED")
+	return strings.ReplaceAll(s, url.QueryEscape(c.apiKey), "REDACTED")
+}
@@ -0,0 +1,112 @@
+package ramaris
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// logRecords decodes the JSON log lines in buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var r map[string]any
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestWithLogger(t *testing.T) {
+	var requests atomic.Int32
+	srv, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "7")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			fmt.Fprint(w, `{"status":"ok"}`)
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"code":"RATE_LIMITED","message":"slow down","retryAfter":30}}`)
+		}
+	})
+
+	for _, tt := range []struct {
+		level slog.Level
+		want  []string
+	}{
+		{slog.LevelDebug, []string{"ramaris: retrying request", "ramaris: request", "ramaris: rate limited"}},
+		{slog.LevelWarn, []string{"ramaris: retrying request", "ramaris: rate limited"}},
+	} {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			requests.Store(0)
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: tt.level}))
+			// The API key and password must not appear even where the URL carries them.
+			base := strings.Replace(srv.URL, "http://", "http://user:hunter2@", 1) + "/rms_secret"
+			c := NewClient("rms_secret", WithBaseURL(base), WithRetryPolicy(ConstantBackoff{MaxRetries: 1}), WithLogger(logger))
+
+			if _, err := c.Health(context.Background()); err != nil {
+				t.Fatalf("Health() error: %v", err)
+			}
+			if _, err := c.Health(context.Background()); err == nil {
+				t.Fatal("Health() error = nil, want rate limit error")
+			}
+
+			out := buf.String()
+			if strings.Contains(out, "rms_secret") || strings.Contains(out, "hunter2") {
+				t.Errorf("log leaks credentials:\n%s", out)
+			}
+			records := logRecords(t, &buf)
+			var msgs []string
+			for _, r := range records {
+				msgs = append(msgs, r["msg"].(string))
+			}
+			if got := strings.Join(msgs, ", "); got != strings.Join(tt.want, ", ") {
+				t.Fatalf("messages = %s, want %s", got, strings.Join(tt.want, ", "))
+			}
+
+			retry := records[0]
+			if retry["level"] != "WARN" || retry["status"] != float64(503) || retry["attempt"] != float64(1) || retry["method"] != "GET" {
+				t.Errorf("retry record = %v, want WARN 503 attempt 1", retry)
+			}
+			if _, ok := retry["retry_delay"]; !ok {
+				t.Errorf("retry record = %v, want retry_delay", retry)
+			}
+			if rl, _ := retry["rate_limit"].(map[string]any); rl["remaining"] != float64(7) {
+				t.Errorf("retry record rate_limit = %v, want remaining 7", retry["rate_limit"])
+			}
+			if url, _ := retry["url"].(string); !strings.HasSuffix(url, "/REDACTED/health") {
+				t.Errorf("url = %q, want redacted API key", url)
+			}
+
+			limited := records[len(records)-1]
+			if limited["level"] != "WARN" || limited["status"] != float64(429) || limited["retry_after"] != float64(30) {
+				t.Errorf("rate limited record = %v, want WARN 429 retry_after 30", limited)
+			}
+			if _, ok := limited["retry_delay"]; ok {
+				t.Errorf("rate limited record = %v, want no retry_delay when not retried", limited)
+			}
+			if tt.level == slog.LevelDebug {
+				if ok := records[1]; ok["level"] != "DEBUG" || ok["status"] != float64(200) || ok["duration"] == nil {
+					t.Errorf("success record = %v, want DEBUG 200 with duration", ok)
+				}
+			}
+		})
+	}
+}
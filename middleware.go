@@ -0,0 +1,141 @@
+package ramaris
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RoundTripFunc sends a single request attempt and returns its response. A
+// non-nil error means no response was received.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to add behavior around request attempts,
+// such as injecting headers, signing requests, audit logging or fault
+// injection.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware adds middleware to the client's request chain. Each API call
+// passes through the chain in this order:
+//
+//  1. retry, which repeats failed attempts per the RetryPolicy and
+//     RateLimitRetry; everything below runs once per attempt
+//  2. rate limiting, which waits for budget under WithRateLimiter and
+//     records the X-RateLimit-* response headers
+//  3. authentication, which sets the Authorization header
+//  4. middleware added with WithMiddleware, the first added outermost
+//  5. the HTTP client
+//
+// Middleware therefore sees each attempt with its final headers, and what it
+// returns is treated like a response from the API: a synthetic 503 is
+// retried, for example. Responses must have a non-nil Body. Middleware also
+// wraps Stream connections, which reconnect on their own instead of being
+// retried.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) { c.middleware = append(c.middleware, mw...) }
+}
+
+// transport returns the chain below retry for call's attempts, ending in hc.
+func (c *Client) transport(call *apiCall, hc *http.Client) RoundTripFunc {
+	rt := RoundTripFunc(hc.Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	rt = c.authenticate(rt)
+	return c.trackRateLimit(call)(rt)
+}
+
+// authenticate sets the API key on each attempt.
+func (c *Client) authenticate(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		return next(req)
+	}
+}
+
+// trackRateLimit waits for the client-side rate limiter, if any, before each
+// attempt and records the rate limit reported by each response.
+func (c *Client) trackRateLimit(call *apiCall) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if c.limiter != nil {
+				if err := c.limiter.wait(req.Context()); err != nil {
+					return nil, err
+				}
+			}
+			resp, err := next(req)
+			if err != nil {
+				return nil, err
+			}
+			if rl := c.updateRateLimit(resp.Header); rl != nil {
+				call.rateLimit = rl
+			}
+			return resp, nil
+		}
+	}
+}
+
+// retry sends each attempt of call as a fresh copy of the request, retrying
+// transport errors and error responses that the RetryPolicy accepts, and 429s
+// as the RateLimitRetry allows. The last response is returned as is, for send
+// to turn into an error. Attempts are logged here, where the delay before the
+// next one is known.
+func (c *Client) retry(call *apiCall) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			retries := 0
+			rateLimitRetries := 0
+
+			for {
+				call.attempts++
+				attempt := req.Clone(ctx)
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					attempt.Body = body
+				}
+
+				start := time.Now()
+				resp, err := next(attempt)
+				a := attemptLog{req: attempt, attempt: call.attempts, resp: resp, err: err, elapsed: time.Since(start)}
+
+				switch {
+				case err != nil:
+					// Transport error — retry if the policy allows and the caller is still waiting
+					if ctx.Err() == nil && c.shouldRetry(call, retries, 0, err) {
+						a.retry, a.delay = true, c.retryPolicy.Delay(retries)
+						retries++
+					}
+
+				case resp.StatusCode == http.StatusTooManyRequests:
+					// The request was not processed, so this is safe for any method.
+					body, _ := io.ReadAll(resp.Body)
+					resp.Body.Close()
+					resp.Body = io.NopCloser(bytes.NewReader(body))
+					a.rateLimited = parseError(resp.StatusCode, body).(*RateLimitError)
+					if d, ok := c.rateLimitRetry.delay(ctx, a.rateLimited, rateLimitRetries); ok {
+						a.retry, a.delay = true, d
+						rateLimitRetries++
+					}
+
+				case resp.StatusCode >= http.StatusBadRequest && c.shouldRetry(call, retries, resp.StatusCode, nil):
+					resp.Body.Close()
+					a.retry, a.delay = true, c.retryPolicy.Delay(retries)
+					retries++
+				}
+
+				c.logAttempt(a)
+				if !a.retry {
+					return resp, err
+				}
+				if err := sleep(ctx, a.delay); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
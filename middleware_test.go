@@ -0,0 +1,116 @@
+package ramaris
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMiddleware_Order(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Team"); got != "quant" {
+			t.Errorf("X-Team = %q, want header injected by middleware", got)
+		}
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+
+	var mu sync.Mutex
+	var trace []string
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("Authorization") == "" {
+					t.Errorf("%s: Authorization not set before user middleware", name)
+				}
+				mu.Lock()
+				trace = append(trace, name+" in")
+				mu.Unlock()
+				resp, err := next(req)
+				mu.Lock()
+				trace = append(trace, name+" out")
+				mu.Unlock()
+				return resp, err
+			}
+		}
+	}
+	injectHeader := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Team", "quant")
+			return next(req)
+		}
+	}
+	WithMiddleware(record("a"), record("b"))(c)
+	WithMiddleware(injectHeader)(c)
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+	want := "a in, b in, b out, a out"
+	if got := strings.Join(trace, ", "); got != want {
+		t.Errorf("trace = %s, want %s", got, want)
+	}
+}
+
+func TestMiddleware_SeesEachAttempt(t *testing.T) {
+	var bodies []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"shareId":"new"}}`)
+	})
+	WithRetryPolicy(ConstantBackoff{MaxRetries: 2})(c)
+
+	// Fail the first attempt without reaching the server, as a chaos test
+	// would; the synthetic 503 is retried like a real one.
+	attempts := 0
+	WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Header:     make(http.Header),
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}, nil
+			}
+			return next(req)
+		}
+	})(c)
+
+	st, err := c.CreateStrategy(context.Background(), CreateStrategyRequest{Name: "Fresh"})
+	if err != nil {
+		t.Fatalf("CreateStrategy() error: %v", err)
+	}
+	if st.ShareID != "new" || attempts != 2 {
+		t.Errorf("ShareID = %q after %d attempts, want new after 2", st.ShareID, attempts)
+	}
+	if len(bodies) != 1 || !strings.Contains(bodies[0], `"name":"Fresh"`) {
+		t.Errorf("server bodies = %q, want the full request body on the retry", bodies)
+	}
+}
+
+func TestMiddleware_Stream(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Signature"); got != "signed" {
+			t.Errorf("X-Signature = %q, want stream connection to pass through middleware", got)
+		}
+		startStream(w)
+	})
+	WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Signature", "signed")
+			return next(req)
+		}
+	})(c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := c.Stream(ctx, nil); err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+}
@@ -0,0 +1,307 @@
+package ramaris
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// queryEncoder is implemented by option types that map to query parameters.
+// Implementations must accept a nil receiver.
+type queryEncoder interface {
+	encode(params url.Values)
+}
+
+// SortOrder is the direction of a sorted list.
+type SortOrder string
+
+// Sort orders.
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// StrategySortField is a field strategies can be sorted by.
+type StrategySortField string
+
+// Strategy sort fields.
+const (
+	StrategySortROI            StrategySortField = "roiPercent"
+	StrategySortLastActivity   StrategySortField = "lastActivityAt"
+	StrategySortCreatedAt      StrategySortField = "createdAt"
+	StrategySortWalletsTracked StrategySortField = "walletsTracked"
+)
+
+// StrategyListOptions configures pagination, filtering and sorting for
+// FilterStrategies.
+type StrategyListOptions struct {
+	ListOptions
+
+	// SortBy orders results by a field; SortOrder defaults to the API's
+	// order for that field when empty.
+	SortBy    StrategySortField
+	SortOrder SortOrder
+
+	// MinROI keeps strategies with ROIPercent >= MinROI.
+	MinROI *float64
+	// Tags keeps strategies carrying all of the given tags.
+	Tags []string
+	// Creator keeps strategies created by this nickname.
+	Creator string
+	// Search matches against strategy name and description.
+	Search string
+}
+
+// WalletSortField is a field wallets can be sorted by.
+type WalletSortField string
+
+// Wallet sort fields.
+const (
+	WalletSortRealizedPnL WalletSortField = "realizedPnL"
+	WalletSortWinRate     WalletSortField = "winRate"
+	WalletSortTotalSwaps  WalletSortField = "totalSwaps"
+	WalletSortCreatedAt   WalletSortField = "createdAt"
+)
+
+// WalletListOptions configures pagination, filtering and sorting for
+// FilterWallets.
+type WalletListOptions struct {
+	ListOptions
+
+	// SortBy orders results by a field; SortOrder defaults to the API's
+	// order for that field when empty.
+	SortBy    WalletSortField
+	SortOrder SortOrder
+
+	// MinWinRate and MaxWinRate bound WinRate (0 to 1), inclusive.
+	MinWinRate *float64
+	MaxWinRate *float64
+	// MinRealizedPnL keeps wallets with RealizedPnL >= MinRealizedPnL.
+	MinRealizedPnL *float64
+	// Tags keeps wallets carrying all of the given tags.
+	Tags []string
+	// ExcludeTags drops wallets carrying any of the given tags.
+	ExcludeTags []string
+	// MinOpenPositions keeps wallets with at least this many open positions.
+	MinOpenPositions int
+}
+
+// SwapListOptions configures cursor pagination and time filters for ListWalletSwaps.
+type SwapListOptions struct {
+	// Cursor is CursorPagination.NextCursor from a previous page. Empty
+	// starts from the most recent swap.
+	Cursor string
+	// Limit is the maximum number of swaps per page.
+	Limit int
+	// Since and Until bound Timestamp, inclusive. Zero values are unbounded.
+	Since time.Time
+	Until time.Time
+}
+
+// NotificationListOptions configures pagination and filters for
+// ListNotifications and ListStrategyNotifications.
+type NotificationListOptions struct {
+	ListOptions
+
+	// Since and Until bound CreatedAt, inclusive. Zero values are unbounded.
+	Since time.Time
+	Until time.Time
+	// Read filters by read state; nil returns both read and unread.
+	Read *bool
+	// Types keeps notifications of any of the given types.
+	Types []NotificationType
+}
+
+func (o *ListOptions) encode(params url.Values) {
+	if o == nil {
+		return
+	}
+	if o.Page > 0 {
+		params.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PageSize > 0 {
+		params.Set("pageSize", strconv.Itoa(o.PageSize))
+	}
+}
+
+func (o *StrategyListOptions) encode(params url.Values) {
+	if o == nil {
+		return
+	}
+	o.ListOptions.encode(params)
+	setString(params, "sortBy", string(o.SortBy))
+	setString(params, "sortOrder", string(o.SortOrder))
+	setFloat(params, "minRoi", o.MinROI)
+	setList(params, "tags", o.Tags)
+	setString(params, "creator", o.Creator)
+	setString(params, "search", o.Search)
+}
+
+func (o *WalletListOptions) encode(params url.Values) {
+	if o == nil {
+		return
+	}
+	o.ListOptions.encode(params)
+	setString(params, "sortBy", string(o.SortBy))
+	setString(params, "sortOrder", string(o.SortOrder))
+	setFloat(params, "minWinRate", o.MinWinRate)
+	setFloat(params, "maxWinRate", o.MaxWinRate)
+	setFloat(params, "minRealizedPnl", o.MinRealizedPnL)
+	setList(params, "tags", o.Tags)
+	setList(params, "excludeTags", o.ExcludeTags)
+	if o.MinOpenPositions > 0 {
+		params.Set("minOpenPositions", strconv.Itoa(o.MinOpenPositions))
+	}
+}
+
+func (o *SwapListOptions) encode(params url.Values) {
+	if o == nil {
+		return
+	}
+	setString(params, "cursor", o.Cursor)
+	if o.Limit > 0 {
+		params.Set("limit", strconv.Itoa(o.Limit))
+	}
+	setTime(params, "since", o.Since)
+	setTime(params, "until", o.Until)
+}
+
+func (o *NotificationListOptions) encode(params url.Values) {
+	if o == nil {
+		return
+	}
+	o.ListOptions.encode(params)
+	setTime(params, "since", o.Since)
+	setTime(params, "until", o.Until)
+	if o.Read != nil {
+		params.Set("read", strconv.FormatBool(*o.Read))
+	}
+	if len(o.Types) > 0 {
+		types := make([]string, len(o.Types))
+		for i, t := range o.Types {
+			types[i] = string(t)
+		}
+		setList(params, "types", types)
+	}
+}
+
+// startPage and withPage let the iterators step through pages. Both accept a
+// nil receiver, and withPage returns a copy so that concurrent iterations
+// don't share options.
+
+func (o *ListOptions) startPage() int {
+	if o == nil {
+		return 0
+	}
+	return o.Page
+}
+
+func (o *ListOptions) withPage(page int) *ListOptions {
+	var c ListOptions
+	if o != nil {
+		c = *o
+	}
+	c.Page = page
+	return &c
+}
+
+func (o *StrategyListOptions) startPage() int {
+	if o == nil {
+		return 0
+	}
+	return o.Page
+}
+
+func (o *StrategyListOptions) withPage(page int) *StrategyListOptions {
+	var c StrategyListOptions
+	if o != nil {
+		c = *o
+	}
+	c.Page = page
+	return &c
+}
+
+func (o *WalletListOptions) startPage() int {
+	if o == nil {
+		return 0
+	}
+	return o.Page
+}
+
+func (o *WalletListOptions) withPage(page int) *WalletListOptions {
+	var c WalletListOptions
+	if o != nil {
+		c = *o
+	}
+	c.Page = page
+	return &c
+}
+
+func (o *NotificationListOptions) startPage() int {
+	if o == nil {
+		return 0
+	}
+	return o.Page
+}
+
+func (o *NotificationListOptions) withPage(page int) *NotificationListOptions {
+	var c NotificationListOptions
+	if o != nil {
+		c = *o
+	}
+	c.Page = page
+	return &c
+}
+
+func (o *SwapListOptions) startCursor() string {
+	if o == nil {
+		return ""
+	}
+	return o.Cursor
+}
+
+func (o *SwapListOptions) withCursor(cursor string) *SwapListOptions {
+	var c SwapListOptions
+	if o != nil {
+		c = *o
+	}
+	c.Cursor = cursor
+	return &c
+}
+
+func setString(params url.Values, key, v string) {
+	if v != "" {
+		params.Set(key, v)
+	}
+}
+
+func setFloat(params url.Values, key string, v *float64) {
+	if v != nil {
+		params.Set(key, strconv.FormatFloat(*v, 'f', -1, 64))
+	}
+}
+
+func setTime(params url.Values, key string, v time.Time) {
+	if !v.IsZero() {
+		params.Set(key, v.UTC().Format(time.RFC3339Nano))
+	}
+}
+
+func setList(params url.Values, key string, v []string) {
+	if len(v) > 0 {
+		params.Set(key, strings.Join(v, ","))
+	}
+}
+
+// Float64 returns a pointer to v, for optional numeric filters such as
+// StrategyListOptions.MinROI or WalletListOptions.MinWinRate.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// Bool returns a pointer to v, for optional filters such as
+// NotificationListOptions.Read.
+func Bool(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,147 @@
+package ramaris
+
+import (
+	"context"
+	"iter"
+)
+
+// Strategies returns an iterator over all strategies, fetching pages lazily.
+// Iteration starts at opts.Page (or the first page) and stops after the last
+// page, on the first error, or when the caller breaks out of the loop.
+func (c *Client) Strategies(ctx context.Context, opts *ListOptions) iter.Seq2[StrategyListItem, error] {
+	return listPages(ctx, opts, c.ListStrategies)
+}
+
+// FilteredStrategies returns an iterator over the strategies matching opts'
+// filters, in opts' order, fetching pages lazily.
+func (c *Client) FilteredStrategies(ctx context.Context, opts *StrategyListOptions) iter.Seq2[StrategyListItem, error] {
+	return listPages(ctx, opts, c.FilterStrategies)
+}
+
+// StrategyWallets returns an iterator over the wallets tracked by a strategy, fetching pages lazily.
+func (c *Client) StrategyWallets(ctx context.Context, shareID string, opts *ListOptions) iter.Seq2[StrategyWallet, error] {
+	return listPages(ctx, opts, func(ctx context.Context, o *ListOptions) (*ListResponse[StrategyWallet], error) {
+		return c.ListStrategyWallets(ctx, shareID, o)
+	})
+}
+
+// StrategyNotifications returns an iterator over a strategy's notifications, fetching pages lazily.
+func (c *Client) StrategyNotifications(ctx context.Context, shareID string, opts *NotificationListOptions) iter.Seq2[Notification, error] {
+	return listPages(ctx, opts, func(ctx context.Context, o *NotificationListOptions) (*ListResponse[Notification], error) {
+		return c.ListStrategyNotifications(ctx, shareID, o)
+	})
+}
+
+// Notifications returns an iterator over the authenticated user's notifications, fetching pages lazily.
+func (c *Client) Notifications(ctx context.Context, opts *NotificationListOptions) iter.Seq2[Notification, error] {
+	return listPages(ctx, opts, c.ListNotifications)
+}
+
+// Wallets returns an iterator over all wallets, fetching pages lazily.
+func (c *Client) Wallets(ctx context.Context, opts *ListOptions) iter.Seq2[WalletListItem, error] {
+	return listPages(ctx, opts, c.ListWallets)
+}
+
+// FilteredWallets returns an iterator over the wallets matching opts' filters,
+// in opts' order, fetching pages lazily.
+func (c *Client) FilteredWallets(ctx context.Context, opts *WalletListOptions) iter.Seq2[WalletListItem, error] {
+	return listPages(ctx, opts, c.FilterWallets)
+}
+
+// Watchlist returns an iterator over the authenticated user's watchlist, fetching pages lazily.
+func (c *Client) Watchlist(ctx context.Context, opts *ListOptions) iter.Seq2[WatchlistStrategy, error] {
+	return listPages(ctx, opts, c.ListWatchlist)
+}
+
+// FollowedWallets returns an iterator over the wallets the authenticated user follows, fetching pages lazily.
+func (c *Client) FollowedWallets(ctx context.Context, opts *ListOptions) iter.Seq2[FollowedWallet, error] {
+	return listPages(ctx, opts, c.ListFollowedWallets)
+}
+
+// WalletSwaps returns an iterator over a wallet's swaps, most recent first,
+// following cursors lazily from opts.Cursor (or the most recent swap).
+func (c *Client) WalletSwaps(ctx context.Context, walletID int, opts *SwapListOptions) iter.Seq2[Swap, error] {
+	return paginateCursor(ctx, opts.startCursor(), func(ctx context.Context, cursor string) (*CursorResponse[Swap], error) {
+		return c.ListWalletSwaps(ctx, walletID, opts.withCursor(cursor))
+	})
+}
+
+// pageOptions is implemented by the options of page-numbered list methods.
+type pageOptions[O any] interface {
+	startPage() int
+	withPage(page int) O
+}
+
+// listPages returns an iterator over a page-numbered list method. Each page is
+// fetched with its own copy of opts, so the iterator may be ranged over more
+// than once, and concurrently.
+func listPages[O pageOptions[O], T any](ctx context.Context, opts O, list func(context.Context, O) (*ListResponse[T], error)) iter.Seq2[T, error] {
+	return paginate(ctx, opts.startPage(), func(ctx context.Context, page int) (*ListResponse[T], error) {
+		return list(ctx, opts.withPage(page))
+	})
+}
+
+// paginate walks a page-numbered list endpoint from start (or the first
+// page), yielding each item in order. A failed page fetch is yielded once as
+// an error with the zero value of T, after which iteration ends.
+func paginate[T any](ctx context.Context, start int, fetch func(ctx context.Context, page int) (*ListResponse[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page := max(start, 1)
+
+		for {
+			var zero T
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			resp, err := fetch(ctx, page)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range resp.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(resp.Data) == 0 || page >= resp.Pagination.TotalPages {
+				return
+			}
+			page++
+		}
+	}
+}
+
+// paginateCursor walks a cursor-paginated list endpoint from cursor, yielding
+// each item in order, with the same error and early-exit behavior as paginate.
+func paginateCursor[T any](ctx context.Context, cursor string, fetch func(ctx context.Context, cursor string) (*CursorResponse[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			var zero T
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			resp, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range resp.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !resp.Pagination.HasMore || resp.Pagination.NextCursor == "" {
+				return
+			}
+			cursor = resp.Pagination.NextCursor
+		}
+	}
+}
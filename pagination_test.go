@@ -0,0 +1,237 @@
+package ramaris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// pagedStrategies serves totalPages pages of two strategies each.
+func pagedStrategies(t *testing.T, totalPages int, calls *int) *Client {
+	t.Helper()
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"data": [
+				{"id":%d,"shareId":"s%d","name":"A","createdAt":"2025-01-01T00:00:00Z","creator":{"nickname":"a"},"stats":{}},
+				{"id":%d,"shareId":"s%d","name":"B","createdAt":"2025-01-01T00:00:00Z","creator":{"nickname":"a"},"stats":{}}
+			],
+			"pagination": {"page":%d,"pageSize":2,"totalItems":%d,"totalPages":%d}
+		}`, page*2-1, page*2-1, page*2, page*2, page, totalPages*2, totalPages)
+	})
+	return c
+}
+
+func TestStrategies_AllPages(t *testing.T) {
+	calls := 0
+	c := pagedStrategies(t, 3, &calls)
+
+	var ids []int
+	for s, err := range c.Strategies(context.Background(), &ListOptions{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("Strategies() error: %v", err)
+		}
+		ids = append(ids, s.ID)
+	}
+
+	if len(ids) != 6 {
+		t.Fatalf("len(ids) = %d, want 6", len(ids))
+	}
+	for i, id := range ids {
+		if id != i+1 {
+			t.Errorf("ids[%d] = %d, want %d", i, id, i+1)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestStrategies_EarlyBreak(t *testing.T) {
+	calls := 0
+	c := pagedStrategies(t, 5, &calls)
+
+	n := 0
+	for _, err := range c.Strategies(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("Strategies() error: %v", err)
+		}
+		n++
+		if n == 3 {
+			break
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestStrategies_StartPage(t *testing.T) {
+	calls := 0
+	c := pagedStrategies(t, 3, &calls)
+
+	var first int
+	for s, err := range c.Strategies(context.Background(), &ListOptions{Page: 3}) {
+		if err != nil {
+			t.Fatalf("Strategies() error: %v", err)
+		}
+		if first == 0 {
+			first = s.ID
+		}
+	}
+
+	if first != 5 {
+		t.Errorf("first ID = %d, want 5", first)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestStrategies_ConcurrentIterations(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `{"data":[{"id":%d}],"pagination":{"page":%d,"pageSize":1,"totalItems":4,"totalPages":4}}`, page, page)
+	})
+
+	// One iterator ranged from several goroutines; each run keeps its own page.
+	seq := c.Strategies(context.Background(), &ListOptions{Page: 2})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var ids []int
+			for s, err := range seq {
+				if err != nil {
+					t.Errorf("Strategies() error: %v", err)
+					return
+				}
+				ids = append(ids, s.ID)
+			}
+			if fmt.Sprint(ids) != "[2 3 4]" {
+				t.Errorf("ids = %v, want [2 3 4]", ids)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStrategies_ContextCanceledMidIteration(t *testing.T) {
+	calls := 0
+	c := pagedStrategies(t, 5, &calls)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var gotErr error
+	for _, err := range c.Strategies(ctx, nil) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		cancel()
+	}
+
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", gotErr)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestWallets_Error(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":{"code":"UNAUTHORIZED","message":"bad key"}}`)
+	})
+
+	n := 0
+	var gotErr error
+	for _, err := range c.Wallets(context.Background(), nil) {
+		n++
+		gotErr = err
+	}
+
+	if n != 1 {
+		t.Errorf("yields = %d, want 1", n)
+	}
+	var apiErr *Error
+	if !errors.As(gotErr, &apiErr) || apiErr.Code != "UNAUTHORIZED" {
+		t.Errorf("error = %v, want UNAUTHORIZED *Error", gotErr)
+	}
+}
+
+func TestFilteredWallets_KeepsFilters(t *testing.T) {
+	var queries []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `{"data":[{"id":%d}],"pagination":{"page":%d,"pageSize":1,"totalItems":2,"totalPages":2}}`, page, page)
+	})
+
+	opts := &WalletListOptions{SortBy: WalletSortWinRate, Tags: []string{"whale"}}
+	for _, err := range c.FilteredWallets(context.Background(), opts) {
+		if err != nil {
+			t.Fatalf("FilteredWallets() error: %v", err)
+		}
+	}
+
+	want := "[page=1&sortBy=winRate&tags=whale page=2&sortBy=winRate&tags=whale]"
+	if fmt.Sprint(queries) != want {
+		t.Errorf("queries = %v, want %s", queries, want)
+	}
+	if opts.Page != 0 {
+		t.Errorf("opts.Page = %d, want caller's options left unchanged", opts.Page)
+	}
+}
+
+func TestWatchlist_Empty(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":[],"pagination":{"page":1,"pageSize":50,"totalItems":0,"totalPages":0}}`)
+	})
+
+	for range c.Watchlist(context.Background(), nil) {
+		t.Fatal("Watchlist() yielded an item, want none")
+	}
+}
+
+func TestWalletSwaps_FollowsCursor(t *testing.T) {
+	var cursors []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		w.Header().Set("Content-Type", "application/json")
+		switch cursor {
+		case "":
+			fmt.Fprint(w, `{"data":[{"txHash":"0x1"},{"txHash":"0x2"}],"pagination":{"nextCursor":"p2","hasMore":true}}`)
+		case "p2":
+			fmt.Fprint(w, `{"data":[{"txHash":"0x3"}],"pagination":{"nextCursor":"","hasMore":false}}`)
+		default:
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+	})
+
+	var hashes []string
+	for s, err := range c.WalletSwaps(context.Background(), 456, &SwapListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("WalletSwaps() error: %v", err)
+		}
+		hashes = append(hashes, s.TxHash)
+	}
+
+	if fmt.Sprint(hashes) != "[0x1 0x2 0x3]" {
+		t.Errorf("hashes = %v, want [0x1 0x2 0x3]", hashes)
+	}
+	if fmt.Sprint(cursors) != "[ p2]" {
+		t.Errorf("cursors = %q, want [\"\" \"p2\"]", cursors)
+	}
+}
@@ -1,10 +1,12 @@
 package ramaris
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -33,6 +35,16 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 
+	retryPolicy    RetryPolicy
+	limiter        *rateLimiter
+	cache          Cache
+	flights        *flightGroup
+	rateLimitRetry RateLimitRetry
+	noAutoKeys     bool
+	instrumenter   Instrumenter
+	middleware     []Middleware
+	logger         *slog.Logger
+
 	mu        sync.RWMutex
 	rateLimit *RateLimitInfo
 }
@@ -40,8 +52,9 @@ type Client struct {
 // NewClient creates a new Ramaris API client.
 func NewClient(apiKey string, opts ...Option) *Client {
 	c := &Client{
-		apiKey:  apiKey,
-		baseURL: defaultBaseURL,
+		apiKey:      apiKey,
+		baseURL:     defaultBaseURL,
+		retryPolicy: DefaultRetryPolicy,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -63,108 +76,206 @@ func (c *Client) RateLimit() *RateLimitInfo {
 	return &cp
 }
 
-// doRequest performs an HTTP GET request with auth, rate limit tracking, and retry on 429/5xx.
-func (c *Client) doRequest(ctx context.Context, path string, opts *ListOptions) (*http.Response, error) {
-	reqURL := c.buildURL(path, opts)
+// doRequest performs an HTTP GET request with auth, rate limit tracking, and retries per the client's RetryPolicy and RateLimitRetry.
+func (c *Client) doRequest(ctx context.Context, path string, opts queryEncoder) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, path, opts, nil)
+}
 
-	maxRetries := 3
-	backoff := 500 * time.Millisecond
+// apiCall is a single API call, which may take several attempts.
+type apiCall struct {
+	method string
+	path   string
+	url    string
+	body   []byte
+	// idempotencyKey is sent as the Idempotency-Key header of every attempt.
+	idempotencyKey string
+
+	// attempts and rateLimit are recorded by the middleware chain for
+	// instrumentation.
+	attempts  int
+	rateLimit *RateLimitInfo
+}
 
-	for i := 0; i < maxRetries; i++ {
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("ramaris: failed to create request: %w", err)
+// do performs an HTTP request, sending body (if non-nil) as JSON. Only GET
+// requests are coalesced and cached. Other requests carry an Idempotency-Key
+// header; server and transport errors are retried only for idempotent
+// methods or requests with a key, since a failed POST or PATCH may already
+// have been applied.
+func (c *Client) do(ctx context.Context, method, path string, opts queryEncoder, body any) (resp *http.Response, err error) {
+	call := &apiCall{method: method, path: path, url: c.buildURL(path, opts)}
+	if body != nil {
+		if call.body, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("ramaris: failed to encode request: %w", err)
 		}
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
-		req.Header.Set("Accept", "application/json")
+	}
+
+	if c.instrumenter != nil {
+		var end func(*http.Response, error)
+		ctx, end = c.startCall(ctx, call, path)
+		defer func() { end(resp, err) }()
+	}
+
+	if method != http.MethodGet {
+		call.idempotencyKey = c.idempotencyKey(ctx)
+		return c.send(ctx, call)
+	}
+	if c.flights != nil {
+		// The shared request can outlive this caller, so it gets its own
+		// copy of call to record attempts in.
+		shared := *call
+		return c.flights.do(ctx, call.url, func(ctx context.Context) (*http.Response, error) {
+			return c.send(ctx, &shared)
+		})
+	}
+	return c.send(ctx, call)
+}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("ramaris: request failed: %w", err)
+// send performs call through the middleware chain. GET requests are served
+// from and fill the cache if one is configured; a successful request with any
+// other method evicts the responses it may have changed.
+func (c *Client) send(ctx context.Context, call *apiCall) (*http.Response, error) {
+	var stale *CacheEntry
+	if call.method == http.MethodGet {
+		var cached *http.Response
+		if cached, stale = c.cachedEntry(call.url); cached != nil {
+			return cached, nil
 		}
+	}
 
-		c.updateRateLimit(resp.Header)
+	var reqBody io.Reader
+	if call.body != nil {
+		reqBody = bytes.NewReader(call.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, call.method, call.url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("ramaris: failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if call.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if call.idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, call.idempotencyKey)
+	}
+	if stale != nil {
+		if stale.ETag != "" {
+			req.Header.Set("If-None-Match", stale.ETag)
+		}
+		if stale.LastModified != "" {
+			req.Header.Set("If-Modified-Since", stale.LastModified)
+		}
+	}
 
-		// Success
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return resp, nil
+	resp, err := c.retry(call)(c.transport(call, c.httpClient))(req)
+	if err != nil {
+		// Waits for retries or the rate limiter end with ctx's error as is.
+		if err == ctx.Err() {
+			return nil, err
 		}
+		return nil, fmt.Errorf("ramaris: request failed: %w", err)
+	}
 
-		// Read error body for all error responses
-		body, _ := io.ReadAll(resp.Body)
+	// 304 — cached copy is still current
+	if resp.StatusCode == http.StatusNotModified && stale != nil {
 		resp.Body.Close()
+		c.cache.Set(call.url, stale)
+		return stale.response(), nil
+	}
 
-		// Parse error envelope
-		var errResp errorResponse
-		_ = json.Unmarshal(body, &errResp)
-
-		// 429 — rate limited, return immediately with RetryAfter info
-		if resp.StatusCode == http.StatusTooManyRequests {
-			return nil, &RateLimitError{
-				Code:       codeOrDefault(errResp.Error.Code, "RATE_LIMITED"),
-				Message:    msgOrDefault(errResp.Error.Message, "rate limit exceeded"),
-				StatusCode: 429,
-				RetryAfter: errResp.Error.RetryAfter,
-			}
+	// Success
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		switch {
+		case c.cache == nil:
+		case call.method != http.MethodGet:
+			c.invalidate(call)
+		case resp.StatusCode == http.StatusOK:
+			return c.storeResponse(call.url, resp)
 		}
+		return resp, nil
+	}
 
-		// 5xx — server error, retry with backoff
-		if resp.StatusCode >= 500 && i < maxRetries-1 {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(backoff):
-				backoff *= 2
-				continue
-			}
-		}
+	// Error response, with any retries exhausted
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	return nil, parseError(resp.StatusCode, body)
+}
 
-		// 4xx (non-429) — return immediately
-		return nil, &Error{
-			Code:       codeOrDefault(errResp.Error.Code, "UNKNOWN_ERROR"),
-			Message:    msgOrDefault(errResp.Error.Message, fmt.Sprintf("HTTP %d", resp.StatusCode)),
-			StatusCode: resp.StatusCode,
-		}
+func (c *Client) shouldRetry(call *apiCall, retries, statusCode int, err error) bool {
+	if c.retryPolicy == nil || (!idempotent(call.method) && call.idempotencyKey == "") {
+		return false
+	}
+	return c.retryPolicy.ShouldRetry(retries, statusCode, err)
+}
+
+// idempotent reports whether repeating a request with method has the same
+// effect as sending it once.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
 	}
+	return false
+}
 
-	return nil, fmt.Errorf("ramaris: max retries exceeded")
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
-func (c *Client) buildURL(path string, opts *ListOptions) string {
+func (c *Client) buildURL(path string, opts queryEncoder) string {
 	u := c.baseURL + path
 	if opts == nil {
 		return u
 	}
 
 	params := url.Values{}
-	if opts.Page > 0 {
-		params.Set("page", strconv.Itoa(opts.Page))
-	}
-	if opts.PageSize > 0 {
-		params.Set("pageSize", strconv.Itoa(opts.PageSize))
-	}
+	opts.encode(params)
 	if len(params) == 0 {
 		return u
 	}
 	return u + "?" + params.Encode()
 }
 
-func (c *Client) updateRateLimit(h http.Header) {
+// updateRateLimit records the rate limit reported by a response and returns
+// it, or nil if the response carried no X-RateLimit-* headers.
+func (c *Client) updateRateLimit(h http.Header) *RateLimitInfo {
+	info := parseRateLimit(h)
+	if info == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	c.rateLimit = info
+	c.mu.Unlock()
+
+	if c.limiter != nil {
+		c.limiter.update(*info)
+	}
+	return info
+}
+
+// parseRateLimit returns the rate limit reported by h, or nil if it has no
+// X-RateLimit-* headers.
+func parseRateLimit(h http.Header) *RateLimitInfo {
 	limit := h.Get("X-RateLimit-Limit")
 	remaining := h.Get("X-RateLimit-Remaining")
 	reset := h.Get("X-RateLimit-Reset")
 
 	if limit == "" || remaining == "" || reset == "" {
-		return
+		return nil
 	}
 
 	l, _ := strconv.Atoi(limit)
 	r, _ := strconv.Atoi(remaining)
 	rs, _ := strconv.Atoi(reset)
-
-	c.mu.Lock()
-	c.rateLimit = &RateLimitInfo{Limit: l, Remaining: r, Reset: rs}
-	c.mu.Unlock()
+	return &RateLimitInfo{Limit: l, Remaining: r, Reset: rs}
 }
 
 func codeOrDefault(code, def string) string {
@@ -198,8 +309,18 @@ func (c *Client) Health(ctx context.Context) (*HealthStatus, error) {
 	return &h, nil
 }
 
-// ListStrategies lists strategies with optional pagination.
+// ListStrategies lists strategies with optional pagination. Use
+// FilterStrategies to filter and sort them.
 func (c *Client) ListStrategies(ctx context.Context, opts *ListOptions) (*ListResponse[StrategyListItem], error) {
+	var o *StrategyListOptions
+	if opts != nil {
+		o = &StrategyListOptions{ListOptions: *opts}
+	}
+	return c.FilterStrategies(ctx, o)
+}
+
+// FilterStrategies lists strategies with optional pagination, filtering and sorting.
+func (c *Client) FilterStrategies(ctx context.Context, opts *StrategyListOptions) (*ListResponse[StrategyListItem], error) {
 	resp, err := c.doRequest(ctx, "/strategies", opts)
 	if err != nil {
 		return nil, err
@@ -228,6 +349,116 @@ func (c *Client) GetStrategy(ctx context.Context, shareID string) (*Strategy, er
 	return &envelope.Data, nil
 }
 
+// CreateStrategy creates a strategy owned by the authenticated user. req is
+// validated before sending; a *ValidationError means nothing was sent.
+func (c *Client) CreateStrategy(ctx context.Context, req CreateStrategyRequest) (*Strategy, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, http.MethodPost, "/strategies", nil, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result singleResponse[Strategy]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result.Data, nil
+}
+
+// UpdateStrategy changes the set fields of one of the authenticated user's
+// strategies and returns the updated strategy. req is validated before
+// sending; a *ValidationError means nothing was sent.
+func (c *Client) UpdateStrategy(ctx context.Context, shareID string, req UpdateStrategyRequest) (*Strategy, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, http.MethodPatch, "/strategies/"+shareID, nil, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result singleResponse[Strategy]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result.Data, nil
+}
+
+// DeleteStrategy deletes one of the authenticated user's strategies.
+func (c *Client) DeleteStrategy(ctx context.Context, shareID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, "/strategies/"+shareID, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ListStrategyWallets lists the wallets tracked by a strategy with optional pagination.
+func (c *Client) ListStrategyWallets(ctx context.Context, shareID string, opts *ListOptions) (*ListResponse[StrategyWallet], error) {
+	resp, err := c.doRequest(ctx, "/strategies/"+shareID+"/wallets", opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result ListResponse[StrategyWallet]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// AddWalletToStrategy adds a wallet to the set tracked by one of the
+// authenticated user's strategies. req is validated before sending; a
+// *ValidationError means nothing was sent.
+func (c *Client) AddWalletToStrategy(ctx context.Context, shareID string, req AddStrategyWalletRequest) (*StrategyWallet, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, http.MethodPost, "/strategies/"+shareID+"/wallets", nil, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result singleResponse[StrategyWallet]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result.Data, nil
+}
+
+// RemoveWalletFromStrategy stops one of the authenticated user's strategies
+// from tracking a wallet.
+func (c *Client) RemoveWalletFromStrategy(ctx context.Context, shareID string, walletID int) error {
+	resp, err := c.do(ctx, http.MethodDelete, "/strategies/"+shareID+"/wallets/"+strconv.Itoa(walletID), nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ListStrategyNotifications lists a strategy's notifications, most recent first.
+func (c *Client) ListStrategyNotifications(ctx context.Context, shareID string, opts *NotificationListOptions) (*ListResponse[Notification], error) {
+	resp, err := c.doRequest(ctx, "/strategies/"+shareID+"/notifications", opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result ListResponse[Notification]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
 // ListWatchlist lists the authenticated user's watchlist strategies.
 func (c *Client) ListWatchlist(ctx context.Context, opts *ListOptions) (*ListResponse[WatchlistStrategy], error) {
 	resp, err := c.doRequest(ctx, "/strategies/me/watchlist", opts)
@@ -243,8 +474,45 @@ func (c *Client) ListWatchlist(ctx context.Context, opts *ListOptions) (*ListRes
 	return &result, nil
 }
 
-// ListWallets lists wallets with optional pagination.
+// AddToWatchlist adds the strategy with the given share ID to the
+// authenticated user's watchlist and returns the watchlist entry.
+func (c *Client) AddToWatchlist(ctx context.Context, shareID string) (*WatchlistStrategy, error) {
+	resp, err := c.do(ctx, http.MethodPost, "/strategies/me/watchlist", nil, watchlistRequest{ShareID: shareID})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result singleResponse[WatchlistStrategy]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result.Data, nil
+}
+
+// RemoveFromWatchlist removes the strategy with the given share ID from the
+// authenticated user's watchlist.
+func (c *Client) RemoveFromWatchlist(ctx context.Context, shareID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, "/strategies/me/watchlist/"+shareID, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ListWallets lists wallets with optional pagination. Use FilterWallets to
+// filter and sort them.
 func (c *Client) ListWallets(ctx context.Context, opts *ListOptions) (*ListResponse[WalletListItem], error) {
+	var o *WalletListOptions
+	if opts != nil {
+		o = &WalletListOptions{ListOptions: *opts}
+	}
+	return c.FilterWallets(ctx, o)
+}
+
+// FilterWallets lists wallets with optional pagination, filtering and sorting.
+func (c *Client) FilterWallets(ctx context.Context, opts *WalletListOptions) (*ListResponse[WalletListItem], error) {
 	resp, err := c.doRequest(ctx, "/wallets", opts)
 	if err != nil {
 		return nil, err
@@ -273,6 +541,79 @@ func (c *Client) GetWallet(ctx context.Context, id int) (*Wallet, error) {
 	return &envelope.Data, nil
 }
 
+// ListWalletSwaps lists a wallet's swaps, most recent first, using cursor
+// pagination. Swaps that arrive while paging are not returned on later pages,
+// so pages never shift or repeat items.
+func (c *Client) ListWalletSwaps(ctx context.Context, walletID int, opts *SwapListOptions) (*CursorResponse[Swap], error) {
+	resp, err := c.doRequest(ctx, "/wallets/"+strconv.Itoa(walletID)+"/swaps", opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result CursorResponse[Swap]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// ListWalletPositions lists a wallet's open positions, one per token.
+func (c *Client) ListWalletPositions(ctx context.Context, walletID int) ([]Position, error) {
+	resp, err := c.doRequest(ctx, "/wallets/"+strconv.Itoa(walletID)+"/positions", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var envelope singleResponse[[]Position]
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return envelope.Data, nil
+}
+
+// FollowWallet makes the authenticated user follow a wallet. Following a
+// wallet that is already followed returns the existing follow.
+func (c *Client) FollowWallet(ctx context.Context, walletID int) (*FollowedWallet, error) {
+	resp, err := c.do(ctx, http.MethodPost, "/wallets/"+strconv.Itoa(walletID)+"/follow", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result singleResponse[FollowedWallet]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result.Data, nil
+}
+
+// UnfollowWallet makes the authenticated user stop following a wallet.
+func (c *Client) UnfollowWallet(ctx context.Context, walletID int) error {
+	resp, err := c.do(ctx, http.MethodDelete, "/wallets/"+strconv.Itoa(walletID)+"/follow", nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ListFollowedWallets lists the wallets the authenticated user follows.
+func (c *Client) ListFollowedWallets(ctx context.Context, opts *ListOptions) (*ListResponse[FollowedWallet], error) {
+	resp, err := c.doRequest(ctx, "/me/followed-wallets", opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result ListResponse[FollowedWallet]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
 // GetProfile gets the authenticated user's profile.
 func (c *Client) GetProfile(ctx context.Context) (*UserProfile, error) {
 	resp, err := c.doRequest(ctx, "/me/profile", nil)
@@ -288,6 +629,22 @@ func (c *Client) GetProfile(ctx context.Context) (*UserProfile, error) {
 	return &envelope.Data, nil
 }
 
+// ListNotifications lists the authenticated user's notifications across all
+// strategies, most recent first.
+func (c *Client) ListNotifications(ctx context.Context, opts *NotificationListOptions) (*ListResponse[Notification], error) {
+	resp, err := c.doRequest(ctx, "/me/notifications", opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result ListResponse[Notification]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("ramaris: failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
 // GetSubscription gets the authenticated user's subscription.
 func (c *Client) GetSubscription(ctx context.Context) (*Subscription, error) {
 	resp, err := c.doRequest(ctx, "/me/subscription", nil)
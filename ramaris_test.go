@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -48,6 +49,57 @@ func TestRateLimitError_ImplementsError(t *testing.T) {
 	var _ error = (*RateLimitError)(nil)
 }
 
+func TestError_Is(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrValidation, ErrServer, ErrRateLimited}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"not found", &Error{Code: "NOT_FOUND", StatusCode: 404}, ErrNotFound},
+		{"unauthorized", &Error{Code: "UNAUTHORIZED", StatusCode: 401}, ErrUnauthorized},
+		{"forbidden", &Error{Code: "FORBIDDEN", StatusCode: 403}, ErrForbidden},
+		{"validation", &Error{Code: "VALIDATION_ERROR", StatusCode: 400}, ErrValidation},
+		{"internal", &Error{Code: "INTERNAL_ERROR", StatusCode: 500}, ErrServer},
+		{"unknown code by status", &Error{Code: "GATEWAY_TIMEOUT", StatusCode: 504}, ErrServer},
+		{"unprocessable", &Error{Code: "UNKNOWN_ERROR", StatusCode: 422}, ErrValidation},
+		{"code wins over status", &Error{Code: "NOT_FOUND", StatusCode: 400}, ErrNotFound},
+		{"conflict", &Error{Code: "CONFLICT", StatusCode: 409}, nil},
+		{"rate limited", &RateLimitError{Code: "RATE_LIMITED", StatusCode: 429}, ErrRateLimited},
+		{"client validation", &ValidationError{}, ErrValidation},
+		{"wrapped", fmt.Errorf("get strategy: %w", &Error{Code: "NOT_FOUND", StatusCode: 404}), ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, s := range sentinels {
+				if got := errors.Is(tt.err, s); got != (s == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, s, got, !got)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ValidationErrorDetails(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"code":"VALIDATION_ERROR","message":"invalid request","details":[{"field":"name","message":"already taken"},{"field":"tags","message":"too many tags"}]}}`)
+	})
+
+	_, err := c.CreateStrategy(context.Background(), CreateStrategyRequest{Name: "Dup"})
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("error = %v, want ErrValidation", err)
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || len(apiErr.Fields) != 2 || apiErr.Fields[1] != (FieldError{Field: "tags", Message: "too many tags"}) {
+		t.Fatalf("error = %#v, want two field errors", err)
+	}
+	want := "ramaris: VALIDATION_ERROR: invalid request (name: already taken; tags: too many tags)"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
 // --- JSON unmarshal tests ---
 
 func TestStrategyListItem_Unmarshal(t *testing.T) {
@@ -512,6 +564,54 @@ func TestListStrategies(t *testing.T) {
 	}
 }
 
+func TestFilterStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *StrategyListOptions
+		wantPath string
+	}{
+		{"nil opts", nil, "/strategies"},
+		{"page and size", &StrategyListOptions{ListOptions: ListOptions{Page: 1, PageSize: 10}}, "/strategies?page=1&pageSize=10"},
+		{"sort", &StrategyListOptions{SortBy: StrategySortROI, SortOrder: SortDesc}, "/strategies?sortBy=roiPercent&sortOrder=desc"},
+		{"filters", &StrategyListOptions{
+			MinROI:  Float64(12.5),
+			Tags:    []string{"base", "defi"},
+			Creator: "alice",
+			Search:  "top wallets",
+		}, "/strategies?creator=alice&minRoi=12.5&search=top+wallets&tags=base%2Cdefi"},
+		{"zero min roi", &StrategyListOptions{MinROI: Float64(0)}, "/strategies?minRoi=0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.RequestURI()
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{
+					"data": [{"id":1,"shareId":"s1","name":"Alpha","description":null,"roiPercent":null,"lastActivityAt":null,"createdAt":"2025-01-01T00:00:00Z","creator":{"nickname":"a"},"stats":{"walletsTracked":5,"totalSwaps":100}}],
+					"pagination": {"page":1,"pageSize":50,"totalItems":1,"totalPages":1}
+				}`)
+			})
+
+			resp, err := c.FilterStrategies(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("FilterStrategies() error: %v", err)
+			}
+
+			if gotPath != tt.wantPath {
+				t.Errorf("path = %q, want %q", gotPath, tt.wantPath)
+			}
+			if len(resp.Data) != 1 {
+				t.Fatalf("len(Data) = %d, want 1", len(resp.Data))
+			}
+			if resp.Data[0].Name != "Alpha" {
+				t.Errorf("Data[0].Name = %q, want %q", resp.Data[0].Name, "Alpha")
+			}
+		})
+	}
+}
+
 func TestGetStrategy(t *testing.T) {
 	var gotPath string
 	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -539,6 +639,218 @@ func TestGetStrategy(t *testing.T) {
 	}
 }
 
+func TestCreateStrategy(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/strategies" {
+			t.Errorf("request = %s %s, want POST /strategies", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"name":"Alpha","tags":["base"]}`; string(body) != want {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":9,"shareId":"new","name":"Alpha","status":"ACTIVE","tags":["base"],"createdAt":"2025-03-01T00:00:00Z"}}`)
+	})
+
+	s, err := c.CreateStrategy(context.Background(), CreateStrategyRequest{Name: "Alpha", Tags: []string{"base"}})
+	if err != nil {
+		t.Fatalf("CreateStrategy() error: %v", err)
+	}
+	if s.ShareID != "new" || s.Status != StrategyStatusActive {
+		t.Errorf("strategy = %+v", s)
+	}
+}
+
+func TestCreateStrategy_ValidationError(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+
+	_, err := c.CreateStrategy(context.Background(), CreateStrategyRequest{})
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.Fields[0].Field != "name" {
+		t.Fatalf("error = %v, want name *ValidationError", err)
+	}
+	if calls != 0 {
+		t.Errorf("calls = %d, want 0", calls)
+	}
+}
+
+func TestUpdateStrategy(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/strategies/abc" {
+			t.Errorf("request = %s %s, want PATCH /strategies/abc", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"tags":[],"status":"PAUSED"}`; string(body) != want {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{"shareId":"abc","name":"Alpha","status":"PAUSED","tags":[]}}`)
+	})
+
+	s, err := c.UpdateStrategy(context.Background(), "abc", UpdateStrategyRequest{Tags: []string{}, Status: String(StrategyStatusPaused)})
+	if err != nil {
+		t.Fatalf("UpdateStrategy() error: %v", err)
+	}
+	if s.Status != StrategyStatusPaused {
+		t.Errorf("Status = %q, want PAUSED", s.Status)
+	}
+}
+
+func TestDeleteStrategy(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/strategies/abc" {
+			t.Errorf("request = %s %s, want DELETE /strategies/abc", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.DeleteStrategy(context.Background(), "abc"); err != nil {
+		t.Fatalf("DeleteStrategy() error: %v", err)
+	}
+}
+
+func TestAddWalletToStrategy(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/strategies/abc/wallets" {
+			t.Errorf("request = %s %s, want POST /strategies/abc/wallets", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"walletId":456,"weight":0.5}`; string(body) != want {
+			t.Errorf("body = %s, want %s", body, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":456,"weight":0.5,"addedAt":"2025-03-01T00:00:00Z"}}`)
+	})
+
+	sw, err := c.AddWalletToStrategy(context.Background(), "abc", AddStrategyWalletRequest{WalletID: 456, Weight: Float64(0.5)})
+	if err != nil {
+		t.Fatalf("AddWalletToStrategy() error: %v", err)
+	}
+	if sw.ID != 456 || sw.Weight != 0.5 {
+		t.Errorf("wallet = %+v", sw)
+	}
+}
+
+func TestRemoveWalletFromStrategy(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/strategies/abc/wallets/456" {
+			t.Errorf("request = %s %s, want DELETE /strategies/abc/wallets/456", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.RemoveWalletFromStrategy(context.Background(), "abc", 456); err != nil {
+		t.Fatalf("RemoveWalletFromStrategy() error: %v", err)
+	}
+}
+
+func TestListStrategyWallets(t *testing.T) {
+	var gotURI string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"data": [{"id":456,"winRate":0.7,"realizedPnL":900.0,"createdAt":"2025-01-01T00:00:00Z","stats":{"totalSwaps":40,"openPositions":2},"tags":["whale"],"weight":0.25,"addedAt":"2025-02-10T00:00:00Z"}],
+			"pagination": {"page":2,"pageSize":1,"totalItems":4,"totalPages":4}
+		}`)
+	})
+
+	resp, err := c.ListStrategyWallets(context.Background(), "abc", &ListOptions{Page: 2, PageSize: 1})
+	if err != nil {
+		t.Fatalf("ListStrategyWallets() error: %v", err)
+	}
+
+	if gotURI != "/strategies/abc/wallets?page=2&pageSize=1" {
+		t.Errorf("URI = %q, want %q", gotURI, "/strategies/abc/wallets?page=2&pageSize=1")
+	}
+	if len(resp.Data) != 1 {
+		t.Fatalf("len(Data) = %d, want 1", len(resp.Data))
+	}
+	sw := resp.Data[0]
+	if sw.ID != 456 || sw.Stats.TotalSwaps != 40 || len(sw.Tags) != 1 {
+		t.Errorf("wallet = %+v", sw.WalletListItem)
+	}
+	if sw.Weight != 0.25 {
+		t.Errorf("Weight = %v, want 0.25", sw.Weight)
+	}
+	if !sw.AddedAt.Equal(time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("AddedAt = %v", sw.AddedAt)
+	}
+}
+
+func TestListStrategyNotifications(t *testing.T) {
+	var gotPath, gotQuery string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"data": [
+				{"id":"n3","type":"THRESHOLD_CROSSED","strategyShareId":"abc","walletId":null,"message":"ROI above 20%","read":false,"createdAt":"2025-03-03T00:00:00Z","threshold":{"metric":"roiPercent","threshold":20,"value":21.5,"direction":"above"}},
+				{"id":"n2","type":"POSITION_OPENED","strategyShareId":"abc","walletId":456,"message":"Opened DEGEN","read":false,"createdAt":"2025-03-02T00:00:00Z","position":{"tokenAddress":"0x4ed4","symbol":"DEGEN","size":"100","averageEntryPrice":"0.01","costBasisUsd":"1","unrealizedPnL":"0","openedAt":"2025-03-02T00:00:00Z"}},
+				{"id":"n1","type":"SWAP_DETECTED","strategyShareId":"abc","walletId":456,"message":"Swapped WETH for DEGEN","read":true,"createdAt":"2025-03-01T00:00:00Z","swap":{"txHash":"0xabc","logIndex":1,"blockNumber":10,"timestamp":"2025-03-01T00:00:00Z","tokenIn":{"symbol":"WETH"},"tokenOut":{"symbol":"DEGEN"},"amountIn":"1","amountOut":"100"}}
+			],
+			"pagination": {"page":1,"pageSize":50,"totalItems":3,"totalPages":1}
+		}`)
+	})
+
+	resp, err := c.ListStrategyNotifications(context.Background(), "abc", &NotificationListOptions{
+		Since: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Read:  Bool(false),
+		Types: []NotificationType{NotificationSwapDetected, NotificationThresholdCrossed},
+	})
+	if err != nil {
+		t.Fatalf("ListStrategyNotifications() error: %v", err)
+	}
+
+	if gotPath != "/strategies/abc/notifications" {
+		t.Errorf("path = %q, want %q", gotPath, "/strategies/abc/notifications")
+	}
+	wantQuery := "read=false&since=2025-03-01T00%3A00%3A00Z&types=SWAP_DETECTED%2CTHRESHOLD_CROSSED"
+	if gotQuery != wantQuery {
+		t.Errorf("query = %q, want %q", gotQuery, wantQuery)
+	}
+	if len(resp.Data) != 3 {
+		t.Fatalf("len(Data) = %d, want 3", len(resp.Data))
+	}
+	th, pos, sw := resp.Data[0], resp.Data[1], resp.Data[2]
+	if th.Type != NotificationThresholdCrossed || th.WalletID != nil || th.Threshold == nil || th.Threshold.Value != 21.5 || th.Threshold.Direction != "above" {
+		t.Errorf("threshold notification = %+v", th)
+	}
+	if pos.Type != NotificationPositionOpened || pos.Position == nil || pos.Position.Symbol != "DEGEN" || pos.WalletID == nil || *pos.WalletID != 456 {
+		t.Errorf("position notification = %+v", pos)
+	}
+	if sw.Type != NotificationSwapDetected || sw.Swap == nil || sw.Swap.TxHash != "0xabc" || !sw.Read {
+		t.Errorf("swap notification = %+v", sw)
+	}
+}
+
+func TestListNotifications(t *testing.T) {
+	var gotURI string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":[],"pagination":{"page":2,"pageSize":10,"totalItems":0,"totalPages":0}}`)
+	})
+
+	_, err := c.ListNotifications(context.Background(), &NotificationListOptions{
+		ListOptions: ListOptions{Page: 2, PageSize: 10},
+		Until:       time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Read:        Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("ListNotifications() error: %v", err)
+	}
+	want := "/me/notifications?page=2&pageSize=10&read=true&until=2025-03-01T00%3A00%3A00Z"
+	if gotURI != want {
+		t.Errorf("URI = %q, want %q", gotURI, want)
+	}
+}
+
 func TestListWatchlist(t *testing.T) {
 	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/strategies/me/watchlist" {
@@ -563,6 +875,51 @@ func TestListWatchlist(t *testing.T) {
 	}
 }
 
+func TestAddToWatchlist(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/strategies/me/watchlist" {
+			t.Errorf("request = %s %s, want POST /strategies/me/watchlist", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"shareId":"abc"}` {
+			t.Errorf("body = %s, want {\"shareId\":\"abc\"}", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":1,"shareId":"abc","name":"Alpha","creator":{"nickname":"alice"},"copiedAt":"2025-03-01T00:00:00Z"}}`)
+	})
+
+	ws, err := c.AddToWatchlist(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("AddToWatchlist() error: %v", err)
+	}
+	if ws.ShareID != "abc" || ws.Name != "Alpha" {
+		t.Errorf("entry = %+v", ws)
+	}
+	if !ws.CopiedAt.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("CopiedAt = %v", ws.CopiedAt)
+	}
+}
+
+func TestRemoveFromWatchlist(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/strategies/me/watchlist/abc" {
+			t.Errorf("request = %s %s, want DELETE /strategies/me/watchlist/abc", r.Method, r.URL.Path)
+		}
+		if r.ContentLength > 0 {
+			t.Errorf("ContentLength = %d, want no body", r.ContentLength)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.RemoveFromWatchlist(context.Background(), "abc"); err != nil {
+		t.Fatalf("RemoveFromWatchlist() error: %v", err)
+	}
+}
+
 func TestListWallets(t *testing.T) {
 	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -584,6 +941,39 @@ func TestListWallets(t *testing.T) {
 	}
 }
 
+func TestFilterWallets(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *WalletListOptions
+		wantQuery string
+	}{
+		{"nil opts", nil, ""},
+		{"page", &WalletListOptions{ListOptions: ListOptions{Page: 3, PageSize: 25}}, "page=3&pageSize=25"},
+		{"sort", &WalletListOptions{SortBy: WalletSortRealizedPnL, SortOrder: SortDesc}, "sortBy=realizedPnL&sortOrder=desc"},
+		{"win rate range", &WalletListOptions{MinWinRate: Float64(0.5), MaxWinRate: Float64(0.9)}, "maxWinRate=0.9&minWinRate=0.5"},
+		{"pnl and positions", &WalletListOptions{MinRealizedPnL: Float64(-100), MinOpenPositions: 2}, "minOpenPositions=2&minRealizedPnl=-100"},
+		{"tags", &WalletListOptions{Tags: []string{"whale"}, ExcludeTags: []string{"bot", "mev"}}, "excludeTags=bot%2Cmev&tags=whale"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery string
+			_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				gotQuery = r.URL.RawQuery
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"data":[],"pagination":{"page":1,"pageSize":50,"totalItems":0,"totalPages":0}}`)
+			})
+
+			if _, err := c.FilterWallets(context.Background(), tt.opts); err != nil {
+				t.Fatalf("FilterWallets() error: %v", err)
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("query = %q, want %q", gotQuery, tt.wantQuery)
+			}
+		})
+	}
+}
+
 func TestGetWallet(t *testing.T) {
 	var gotPath string
 	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
@@ -608,6 +998,156 @@ func TestGetWallet(t *testing.T) {
 	}
 }
 
+func TestListWalletSwaps(t *testing.T) {
+	var gotPath, gotQuery string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"data": [{
+				"txHash": "0xabc",
+				"logIndex": 3,
+				"blockNumber": 12345678,
+				"timestamp": "2025-03-01T12:00:00Z",
+				"tokenIn": {"address": "0x4200000000000000000000000000000000000006", "symbol": "WETH", "decimals": 18},
+				"tokenOut": {"address": "0x4ed4e862860bed51a9570b96d89af5e1b0efefed", "symbol": "DEGEN", "decimals": 18},
+				"amountIn": "1.250000000000000001",
+				"amountOut": 150000,
+				"valueUsd": 4100.5,
+				"realizedPnL": null
+			}],
+			"pagination": {"nextCursor": "c2", "hasMore": true}
+		}`)
+	})
+
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 3, 2, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+	resp, err := c.ListWalletSwaps(context.Background(), 456, &SwapListOptions{Cursor: "c1", Limit: 10, Since: since, Until: until})
+	if err != nil {
+		t.Fatalf("ListWalletSwaps() error: %v", err)
+	}
+
+	if gotPath != "/wallets/456/swaps" {
+		t.Errorf("path = %q, want %q", gotPath, "/wallets/456/swaps")
+	}
+	wantQuery := "cursor=c1&limit=10&since=2025-03-01T00%3A00%3A00Z&until=2025-03-01T23%3A00%3A00Z"
+	if gotQuery != wantQuery {
+		t.Errorf("query = %q, want %q", gotQuery, wantQuery)
+	}
+	if len(resp.Data) != 1 {
+		t.Fatalf("len(Data) = %d, want 1", len(resp.Data))
+	}
+	s := resp.Data[0]
+	if s.TxHash != "0xabc" || s.BlockNumber != 12345678 || s.LogIndex != 3 {
+		t.Errorf("swap = %+v", s)
+	}
+	if s.AmountIn != "1.250000000000000001" || s.AmountOut != "150000" {
+		t.Errorf("amounts = %q, %q", s.AmountIn, s.AmountOut)
+	}
+	if s.TokenOut.Symbol != "DEGEN" {
+		t.Errorf("TokenOut.Symbol = %q, want DEGEN", s.TokenOut.Symbol)
+	}
+	if s.ValueUsd == nil || *s.ValueUsd != "4100.5" || s.RealizedPnL != nil {
+		t.Errorf("ValueUsd = %v, RealizedPnL = %v", s.ValueUsd, s.RealizedPnL)
+	}
+	if resp.Pagination.NextCursor != "c2" || !resp.Pagination.HasMore {
+		t.Errorf("Pagination = %+v", resp.Pagination)
+	}
+}
+
+func TestListWalletPositions(t *testing.T) {
+	var gotPath string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":[
+			{"tokenAddress":"0x4ed4e862860bed51a9570b96d89af5e1b0efefed","symbol":"DEGEN","size":"1500000.000000000000000001","averageEntryPrice":"0.0123","costBasisUsd":18450.75,"unrealizedPnL":"-120.5","openedAt":"2025-02-01T08:00:00Z"},
+			{"tokenAddress":"0x0000000000000000000000000000000000000001","symbol":"NEW","size":"10","averageEntryPrice":"1","costBasisUsd":"10","unrealizedPnL":null,"openedAt":"2025-02-02T08:00:00Z"}
+		]}`)
+	})
+
+	positions, err := c.ListWalletPositions(context.Background(), 456)
+	if err != nil {
+		t.Fatalf("ListWalletPositions() error: %v", err)
+	}
+
+	if gotPath != "/wallets/456/positions" {
+		t.Errorf("path = %q, want %q", gotPath, "/wallets/456/positions")
+	}
+	if len(positions) != 2 {
+		t.Fatalf("len(positions) = %d, want 2", len(positions))
+	}
+	p := positions[0]
+	if p.Symbol != "DEGEN" || p.Size != "1500000.000000000000000001" {
+		t.Errorf("position = %+v", p)
+	}
+	if p.CostBasisUsd != "18450.75" || p.UnrealizedPnL != "-120.5" {
+		t.Errorf("CostBasisUsd = %q, UnrealizedPnL = %q", p.CostBasisUsd, p.UnrealizedPnL)
+	}
+	if positions[1].UnrealizedPnL != "" {
+		t.Errorf("UnrealizedPnL = %q, want empty for null", positions[1].UnrealizedPnL)
+	}
+}
+
+func TestFollowWallet(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/wallets/456/follow" {
+			t.Errorf("request = %s %s, want POST /wallets/456/follow", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":456,"winRate":0.7,"tags":["whale"],"followedAt":"2025-03-01T12:00:00Z"}}`)
+	})
+
+	fw, err := c.FollowWallet(context.Background(), 456)
+	if err != nil {
+		t.Fatalf("FollowWallet() error: %v", err)
+	}
+	if fw.ID != 456 || len(fw.Tags) != 1 {
+		t.Errorf("wallet = %+v", fw.WalletListItem)
+	}
+	if !fw.FollowedAt.Equal(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("FollowedAt = %v", fw.FollowedAt)
+	}
+}
+
+func TestUnfollowWallet(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/wallets/456/follow" {
+			t.Errorf("request = %s %s, want DELETE /wallets/456/follow", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.UnfollowWallet(context.Background(), 456); err != nil {
+		t.Fatalf("UnfollowWallet() error: %v", err)
+	}
+}
+
+func TestListFollowedWallets(t *testing.T) {
+	var gotURI string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"data": [{"id":456,"stats":{"totalSwaps":40,"openPositions":2},"followedAt":"2025-03-01T12:00:00Z"}],
+			"pagination": {"page":1,"pageSize":10,"totalItems":1,"totalPages":1}
+		}`)
+	})
+
+	resp, err := c.ListFollowedWallets(context.Background(), &ListOptions{PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListFollowedWallets() error: %v", err)
+	}
+	if gotURI != "/me/followed-wallets?pageSize=10" {
+		t.Errorf("URI = %q, want %q", gotURI, "/me/followed-wallets?pageSize=10")
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Stats.TotalSwaps != 40 || resp.Data[0].FollowedAt.IsZero() {
+		t.Errorf("Data = %+v", resp.Data)
+	}
+}
+
 func TestGetProfile(t *testing.T) {
 	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/me/profile" {
@@ -0,0 +1,1314 @@
+// Package ramaristest provides an in-process fake Ramaris API for tests.
+//
+// A Server holds an in-memory store of strategies, wallets with their swaps
+// and positions, notifications, the watchlist, followed wallets, profile and
+// subscription, and serves them with the same envelopes, pagination, error
+// codes and rate limit headers as the real API. Write endpoints update the
+// store, and events passed to Publish are pushed to live streams. Faults such
+// as 5xx responses, 429s and added latency can be injected per path.
+//
+//	srv := ramaristest.NewServer()
+//	defer srv.Close()
+//	srv.AddStrategy(ramaris.Strategy{ShareID: "abc", Name: "Alpha"})
+//	client := srv.Client()
+package ramaristest
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ramaris "github.com/ramaris-app/go-sdk"
+)
+
+// DefaultAPIKey is the API key accepted by a Server unless WithAPIKey is used.
+const DefaultAPIKey = "rms_test_key"
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAPIKey sets the API key the server accepts.
+func WithAPIKey(key string) Option {
+	return func(s *Server) { s.apiKey = key }
+}
+
+// WithRateLimit sets the number of requests allowed per window. Once the
+// budget is spent, requests get a 429 until the window resets. A limit of
+// zero disables rate limiting and the X-RateLimit-* headers.
+func WithRateLimit(limit int, window time.Duration) Option {
+	return func(s *Server) {
+		s.rateLimit = limit
+		s.rateWindow = window
+	}
+}
+
+// WithHeartbeat sets how often open streams receive a heartbeat comment.
+// Defaults to 15 seconds; zero disables heartbeats.
+func WithHeartbeat(interval time.Duration) Option {
+	return func(s *Server) { s.heartbeat = interval }
+}
+
+// Fault is a failure injected into matching requests.
+type Fault struct {
+	// Path restricts the fault to requests for this path, relative to the
+	// API base (e.g. "/wallets/456"). Empty matches every path.
+	Path string
+	// Status is the HTTP status to respond with. Zero leaves the response
+	// untouched, which is useful for latency-only faults.
+	Status int
+	// RetryAfter is reported in the error envelope of 429 responses.
+	RetryAfter int
+	// Latency delays the response.
+	Latency time.Duration
+	// Times is the number of requests affected. Zero affects every matching
+	// request until ClearFaults is called.
+	Times int
+}
+
+// Server is a fake Ramaris API backed by httptest.Server.
+type Server struct {
+	// URL is the API base URL to pass to ramaris.WithBaseURL.
+	URL string
+
+	srv        *httptest.Server
+	apiKey     string
+	rateLimit  int
+	rateWindow time.Duration
+	heartbeat  time.Duration
+	now        func() time.Time
+	closeOnce  sync.Once
+	closed     chan struct{}
+
+	mu           sync.Mutex
+	strategies   []ramaris.Strategy
+	members      map[string][]ramaris.StrategyWallet
+	wallets      []ramaris.Wallet
+	swaps        map[int][]ramaris.Swap
+	positions    map[int][]ramaris.Position
+	watchlist    []ramaris.WatchlistStrategy
+	following    []ramaris.FollowedWallet
+	alerts       []ramaris.Notification
+	profile      *ramaris.UserProfile
+	subscription *ramaris.Subscription
+	faults       []*Fault
+	replays      map[string]*replay
+	stream       []ramaris.StreamEvent
+	published    chan struct{}
+	disconnect   chan struct{}
+	requests     int
+	remaining    int
+	windowEnd    time.Time
+}
+
+// NewServer starts a fake API server. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		apiKey:     DefaultAPIKey,
+		rateLimit:  100,
+		rateWindow: time.Minute,
+		heartbeat:  15 * time.Second,
+		now:        time.Now,
+		closed:     make(chan struct{}),
+		published:  make(chan struct{}),
+		disconnect: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.handleHealth)
+	mux.HandleFunc("GET /strategies", s.handleListStrategies)
+	mux.HandleFunc("GET /strategies/me/watchlist", s.handleListWatchlist)
+	mux.HandleFunc("POST /strategies/me/watchlist", s.handleAddToWatchlist)
+	mux.HandleFunc("DELETE /strategies/me/watchlist/{shareID}", s.handleRemoveFromWatchlist)
+	mux.HandleFunc("POST /strategies", s.handleCreateStrategy)
+	mux.HandleFunc("GET /strategies/{shareID}", s.handleGetStrategy)
+	mux.HandleFunc("PATCH /strategies/{shareID}", s.handleUpdateStrategy)
+	mux.HandleFunc("DELETE /strategies/{shareID}", s.handleDeleteStrategy)
+	mux.HandleFunc("GET /strategies/{shareID}/wallets", s.handleListStrategyWallets)
+	mux.HandleFunc("POST /strategies/{shareID}/wallets", s.handleAddStrategyWallet)
+	mux.HandleFunc("DELETE /strategies/{shareID}/wallets/{id}", s.handleRemoveStrategyWallet)
+	mux.HandleFunc("GET /strategies/{shareID}/notifications", s.handleListStrategyNotifications)
+	mux.HandleFunc("GET /wallets", s.handleListWallets)
+	mux.HandleFunc("GET /wallets/{id}", s.handleGetWallet)
+	mux.HandleFunc("POST /wallets/{id}/follow", s.handleFollowWallet)
+	mux.HandleFunc("DELETE /wallets/{id}/follow", s.handleUnfollowWallet)
+	mux.HandleFunc("GET /wallets/{id}/swaps", s.handleListWalletSwaps)
+	mux.HandleFunc("GET /wallets/{id}/positions", s.handleListWalletPositions)
+	mux.HandleFunc("GET /me/notifications", s.handleListNotifications)
+	mux.HandleFunc("GET /me/followed-wallets", s.handleListFollowedWallets)
+	mux.HandleFunc("GET /me/stream", s.handleStream)
+	mux.HandleFunc("GET /me/profile", s.handleGetProfile)
+	mux.HandleFunc("GET /me/subscription", s.handleGetSubscription)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "route not found")
+	})
+
+	s.srv = httptest.NewServer(s.middleware(mux))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server, ending any open streams.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() { close(s.closed) })
+	s.srv.Close()
+}
+
+// Client returns a client for this server using the configured API key.
+// opts are applied after the base URL.
+func (s *Server) Client(opts ...ramaris.Option) *ramaris.Client {
+	return ramaris.NewClient(s.apiKey, append([]ramaris.Option{ramaris.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// AddStrategy stores a strategy, replacing any with the same ShareID.
+func (s *Server) AddStrategy(st ramaris.Strategy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.strategies {
+		if s.strategies[i].ShareID == st.ShareID {
+			s.strategies[i] = st
+			return
+		}
+	}
+	s.strategies = append(s.strategies, st)
+}
+
+// AddStrategyWallets adds wallets to the set tracked by the strategy with the
+// given share ID.
+func (s *Server) AddStrategyWallets(shareID string, wallets ...ramaris.StrategyWallet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.members == nil {
+		s.members = make(map[string][]ramaris.StrategyWallet)
+	}
+	s.members[shareID] = append(s.members[shareID], wallets...)
+}
+
+// AddWallet stores a wallet, replacing any with the same ID.
+func (s *Server) AddWallet(w ramaris.Wallet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.wallets {
+		if s.wallets[i].ID == w.ID {
+			s.wallets[i] = w
+			return
+		}
+	}
+	s.wallets = append(s.wallets, w)
+}
+
+// AddSwaps records swaps made by the wallet with the given ID. Swaps are
+// served most recent first, ordered by block number and log index.
+func (s *Server) AddSwaps(walletID int, swaps ...ramaris.Swap) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.swaps == nil {
+		s.swaps = make(map[int][]ramaris.Swap)
+	}
+	s.swaps[walletID] = append(s.swaps[walletID], swaps...)
+}
+
+// SetPositions replaces the open positions of the wallet with the given ID.
+func (s *Server) SetPositions(walletID int, positions ...ramaris.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.positions == nil {
+		s.positions = make(map[int][]ramaris.Position)
+	}
+	s.positions[walletID] = positions
+}
+
+// AddWatchlist adds a strategy to the authenticated user's watchlist.
+func (s *Server) AddWatchlist(ws ramaris.WatchlistStrategy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchlist = append(s.watchlist, ws)
+}
+
+// AddNotifications adds notifications for the authenticated user. They are
+// served most recent first, both user-wide and for their strategy.
+func (s *Server) AddNotifications(ns ...ramaris.Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = append(s.alerts, ns...)
+}
+
+// SetProfile sets the authenticated user's profile.
+func (s *Server) SetProfile(p ramaris.UserProfile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profile = &p
+}
+
+// SetSubscription sets the authenticated user's subscription.
+func (s *Server) SetSubscription(sub ramaris.Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscription = &sub
+}
+
+// InjectFault adds a fault. Faults are checked in the order they were added
+// and the first match applies.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the number of requests the server has received.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// middleware applies faults, authentication and rate limiting, in that order.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests++
+		fault := s.takeFault(r.URL.Path)
+		s.mu.Unlock()
+
+		if fault != nil && fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault != nil && fault.Status != 0 {
+			if fault.Status == http.StatusTooManyRequests {
+				writeRateLimited(w, fault.RetryAfter)
+				return
+			}
+			writeError(w, fault.Status, codeForStatus(fault.Status), http.StatusText(fault.Status))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+s.apiKey {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid or missing API key")
+			return
+		}
+
+		if !s.allow(w) {
+			return
+		}
+		if key := r.Header.Get("Idempotency-Key"); key != "" && r.Method != http.MethodGet {
+			s.serveIdempotent(w, r, key, next)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// replay is the response to the first request with an idempotency key. done
+// is closed once rec is set.
+type replay struct {
+	done chan struct{}
+	rec  *httptest.ResponseRecorder
+}
+
+// serveIdempotent serves a mutating request carrying an Idempotency-Key. The
+// first response for a method, path and key (other than a 5xx) is recorded
+// and replayed for repeats instead of applying the change again. The key is
+// reserved before the change is applied, so concurrent repeats wait for the
+// first response rather than applying it too.
+func (s *Server) serveIdempotent(w http.ResponseWriter, r *http.Request, key string, next http.Handler) {
+	id := r.Method + " " + r.URL.Path + " " + key
+	for {
+		s.mu.Lock()
+		if s.replays == nil {
+			s.replays = make(map[string]*replay)
+		}
+		rp, ok := s.replays[id]
+		if !ok {
+			rp = &replay{done: make(chan struct{})}
+			s.replays[id] = rp
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			rp.rec = httptest.NewRecorder()
+			next.ServeHTTP(rp.rec, r)
+			if rp.rec.Code >= 500 {
+				// Server errors are not replayed, so a retry applies the change.
+				s.mu.Lock()
+				delete(s.replays, id)
+				s.mu.Unlock()
+			}
+			close(rp.done)
+			writeRecorded(w, rp.rec)
+			return
+		}
+
+		select {
+		case <-rp.done:
+		case <-r.Context().Done():
+			return
+		}
+		if rp.rec.Code < 500 {
+			writeRecorded(w, rp.rec)
+			return
+		}
+		// The first request failed without applying the change; try again.
+	}
+}
+
+// writeRecorded copies a recorded response to w.
+func writeRecorded(w http.ResponseWriter, rec *httptest.ResponseRecorder) {
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+// takeFault returns the first fault matching path and uses up one of its
+// Times. Callers must hold s.mu.
+func (s *Server) takeFault(path string) *Fault {
+	for i, f := range s.faults {
+		if f.Path != "" && f.Path != path {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// allow spends one request from the rate limit budget, writing the
+// X-RateLimit-* headers, or writes a 429 and reports false.
+func (s *Server) allow(w http.ResponseWriter) bool {
+	if s.rateLimit <= 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	if !now.Before(s.windowEnd) {
+		s.windowEnd = now.Add(s.rateWindow)
+		s.remaining = s.rateLimit
+	}
+	limited := s.remaining <= 0
+	if !limited {
+		s.remaining--
+	}
+	remaining, windowEnd := s.remaining, s.windowEnd
+	s.mu.Unlock()
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.rateLimit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(windowEnd.Unix(), 10))
+
+	if limited {
+		writeRateLimited(w, int(math.Ceil(windowEnd.Sub(now).Seconds())))
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	prefix := s.apiKey
+	if len(prefix) > 8 {
+		prefix = prefix[:8]
+	}
+	writeJSON(w, http.StatusOK, ramaris.HealthStatus{
+		Status:    "ok",
+		Version:   "test",
+		Timestamp: s.now().UTC().Format(time.RFC3339),
+		User:      "test",
+		RateLimit: ramaris.HealthRateLimit{Limit: s.rateLimit, KeyPrefix: prefix},
+	})
+}
+
+func (s *Server) handleListStrategies(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	minROI, ok := queryFloat(w, r, "minRoi")
+	if !ok {
+		return
+	}
+	tags := splitList(q.Get("tags"))
+	creator := q.Get("creator")
+	search := strings.ToLower(q.Get("search"))
+
+	s.mu.Lock()
+	var matched []ramaris.Strategy
+	for _, st := range s.strategies {
+		switch {
+		case minROI != nil && (st.ROIPercent == nil || *st.ROIPercent < *minROI),
+			!hasAll(st.Tags, tags),
+			creator != "" && st.Creator.Nickname != creator,
+			search != "" && !strings.Contains(strings.ToLower(st.Name+" "+deref(st.Description)), search):
+			continue
+		}
+		matched = append(matched, st)
+	}
+	s.mu.Unlock()
+
+	var key func(ramaris.Strategy) float64
+	switch ramaris.StrategySortField(q.Get("sortBy")) {
+	case "":
+	case ramaris.StrategySortROI:
+		key = func(st ramaris.Strategy) float64 { return derefFloat(st.ROIPercent) }
+	case ramaris.StrategySortLastActivity:
+		key = func(st ramaris.Strategy) float64 { return unixTime(st.LastActivityAt) }
+	case ramaris.StrategySortCreatedAt:
+		key = func(st ramaris.Strategy) float64 { return unixTime(&st.CreatedAt) }
+	case ramaris.StrategySortWalletsTracked:
+		key = func(st ramaris.Strategy) float64 { return float64(st.Stats.WalletsTracked) }
+	default:
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "unsupported sortBy")
+		return
+	}
+	if !sortBy(w, q.Get("sortOrder"), matched, key) {
+		return
+	}
+
+	items := make([]ramaris.StrategyListItem, len(matched))
+	for i, st := range matched {
+		items[i] = ramaris.StrategyListItem{
+			ID:             st.ID,
+			ShareID:        st.ShareID,
+			Name:           st.Name,
+			Description:    st.Description,
+			ROIPercent:     st.ROIPercent,
+			LastActivityAt: st.LastActivityAt,
+			CreatedAt:      st.CreatedAt,
+			Creator:        st.Creator,
+			Stats: ramaris.StrategyStats{
+				WalletsTracked: st.Stats.WalletsTracked,
+				TotalSwaps:     st.Stats.TotalSwaps,
+			},
+		}
+	}
+	writePage(w, r, items)
+}
+
+func (s *Server) handleGetStrategy(w http.ResponseWriter, r *http.Request) {
+	shareID := r.PathValue("shareID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, st := range s.strategies {
+		if st.ShareID == shareID {
+			writeData(w, st)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not found")
+}
+
+func (s *Server) handleCreateStrategy(w http.ResponseWriter, r *http.Request) {
+	var req ramaris.CreateStrategyRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := 1
+	for _, st := range s.strategies {
+		id = max(id, st.ID+1)
+	}
+	st := ramaris.Strategy{
+		ID:        id,
+		ShareID:   fmt.Sprintf("st%d", id),
+		Name:      req.Name,
+		CreatedAt: s.now().UTC(),
+		Status:    cmp.Or(req.Status, ramaris.StrategyStatusActive),
+		Tags:      append([]string{}, req.Tags...),
+	}
+	if req.Description != "" {
+		st.Description = &req.Description
+	}
+	if s.profile != nil && s.profile.Nickname != nil {
+		st.Creator.Nickname = *s.profile.Nickname
+	}
+	s.strategies = append(s.strategies, st)
+	writeJSON(w, http.StatusCreated, map[string]any{"data": st})
+}
+
+func (s *Server) handleUpdateStrategy(w http.ResponseWriter, r *http.Request) {
+	var req ramaris.UpdateStrategyRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.strategy(r.PathValue("shareID"))
+	if st == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not found")
+		return
+	}
+	if req.Name != nil {
+		st.Name = *req.Name
+	}
+	if req.Description != nil {
+		st.Description = nil
+		if *req.Description != "" {
+			st.Description = req.Description
+		}
+	}
+	if req.Tags != nil {
+		st.Tags = req.Tags
+	}
+	if req.Status != nil {
+		st.Status = *req.Status
+	}
+	writeData(w, st)
+}
+
+func (s *Server) handleDeleteStrategy(w http.ResponseWriter, r *http.Request) {
+	shareID := r.PathValue("shareID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.strategies, func(st ramaris.Strategy) bool { return st.ShareID == shareID })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not found")
+		return
+	}
+	s.strategies = slices.Delete(s.strategies, i, i+1)
+	delete(s.members, shareID)
+	s.watchlist = slices.DeleteFunc(s.watchlist, func(ws ramaris.WatchlistStrategy) bool { return ws.ShareID == shareID })
+	s.alerts = slices.DeleteFunc(s.alerts, func(n ramaris.Notification) bool { return n.StrategyShareID == shareID })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAddStrategyWallet(w http.ResponseWriter, r *http.Request) {
+	var req ramaris.AddStrategyWalletRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+
+	shareID := r.PathValue("shareID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.strategy(shareID) == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not found")
+		return
+	}
+	i := slices.IndexFunc(s.wallets, func(wl ramaris.Wallet) bool { return wl.ID == req.WalletID })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "wallet not found")
+		return
+	}
+	if slices.ContainsFunc(s.members[shareID], func(sw ramaris.StrategyWallet) bool { return sw.ID == req.WalletID }) {
+		writeError(w, http.StatusConflict, "CONFLICT", "wallet already tracked by strategy")
+		return
+	}
+
+	wl := s.wallets[i]
+	sw := ramaris.StrategyWallet{
+		WalletListItem: listItem(wl),
+		Weight:         1,
+		AddedAt:        s.now().UTC(),
+	}
+	if req.Weight != nil {
+		sw.Weight = *req.Weight
+	}
+	if s.members == nil {
+		s.members = make(map[string][]ramaris.StrategyWallet)
+	}
+	s.members[shareID] = append(s.members[shareID], sw)
+	writeJSON(w, http.StatusCreated, map[string]any{"data": sw})
+}
+
+func (s *Server) handleRemoveStrategyWallet(w http.ResponseWriter, r *http.Request) {
+	shareID := r.PathValue("shareID")
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "wallet id must be an integer")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.strategy(shareID) == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not found")
+		return
+	}
+	members := s.members[shareID]
+	i := slices.IndexFunc(members, func(sw ramaris.StrategyWallet) bool { return sw.ID == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "wallet not tracked by strategy")
+		return
+	}
+	s.members[shareID] = slices.Delete(members, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// strategy returns the stored strategy with the given share ID, or nil.
+// Callers must hold s.mu.
+func (s *Server) strategy(shareID string) *ramaris.Strategy {
+	for i := range s.strategies {
+		if s.strategies[i].ShareID == shareID {
+			return &s.strategies[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleListStrategyWallets(w http.ResponseWriter, r *http.Request) {
+	shareID := r.PathValue("shareID")
+	s.mu.Lock()
+	if !slices.ContainsFunc(s.strategies, func(st ramaris.Strategy) bool { return st.ShareID == shareID }) {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not found")
+		return
+	}
+	items := slices.Clone(s.members[shareID])
+	s.mu.Unlock()
+	writePage(w, r, items)
+}
+
+func (s *Server) handleListStrategyNotifications(w http.ResponseWriter, r *http.Request) {
+	shareID := r.PathValue("shareID")
+	s.mu.Lock()
+	found := slices.ContainsFunc(s.strategies, func(st ramaris.Strategy) bool { return st.ShareID == shareID })
+	s.mu.Unlock()
+	if !found {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not found")
+		return
+	}
+	s.listNotifications(w, r, shareID)
+}
+
+func (s *Server) handleListNotifications(w http.ResponseWriter, r *http.Request) {
+	s.listNotifications(w, r, "")
+}
+
+// listNotifications writes the notifications matching the since, until,
+// read and types query parameters, restricted to shareID if it is non-empty.
+func (s *Server) listNotifications(w http.ResponseWriter, r *http.Request, shareID string) {
+	q := r.URL.Query()
+	since, ok := queryTime(w, r, "since")
+	if !ok {
+		return
+	}
+	until, ok := queryTime(w, r, "until")
+	if !ok {
+		return
+	}
+	var read *bool
+	if v := q.Get("read"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "read must be true or false")
+			return
+		}
+		read = &b
+	}
+	types := splitList(q.Get("types"))
+
+	s.mu.Lock()
+	var items []ramaris.Notification
+	for _, n := range s.alerts {
+		if (shareID != "" && n.StrategyShareID != shareID) ||
+			(!since.IsZero() && n.CreatedAt.Before(since)) ||
+			(!until.IsZero() && n.CreatedAt.After(until)) ||
+			(read != nil && n.Read != *read) ||
+			(len(types) > 0 && !slices.Contains(types, string(n.Type))) {
+			continue
+		}
+		items = append(items, n)
+	}
+	s.mu.Unlock()
+
+	slices.SortStableFunc(items, func(a, b ramaris.Notification) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	writePage(w, r, items)
+}
+
+func (s *Server) handleListWatchlist(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	items := append([]ramaris.WatchlistStrategy(nil), s.watchlist...)
+	s.mu.Unlock()
+	writePage(w, r, items)
+}
+
+func (s *Server) handleAddToWatchlist(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ShareID string `json:"shareId"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.ShareID == "" {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "shareId is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ws := range s.watchlist {
+		if ws.ShareID == req.ShareID {
+			writeData(w, ws)
+			return
+		}
+	}
+	i := slices.IndexFunc(s.strategies, func(st ramaris.Strategy) bool { return st.ShareID == req.ShareID })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not found")
+		return
+	}
+	st := s.strategies[i]
+	ws := ramaris.WatchlistStrategy{
+		ID:             st.ID,
+		ShareID:        st.ShareID,
+		Name:           st.Name,
+		Description:    st.Description,
+		ROIPercent:     st.ROIPercent,
+		LastActivityAt: st.LastActivityAt,
+		Creator:        st.Creator,
+		CopiedAt:       s.now().UTC(),
+	}
+	s.watchlist = append(s.watchlist, ws)
+	writeJSON(w, http.StatusCreated, map[string]any{"data": ws})
+}
+
+func (s *Server) handleRemoveFromWatchlist(w http.ResponseWriter, r *http.Request) {
+	shareID := r.PathValue("shareID")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.watchlist, func(ws ramaris.WatchlistStrategy) bool { return ws.ShareID == shareID })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "strategy not in watchlist")
+		return
+	}
+	s.watchlist = slices.Delete(s.watchlist, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListWallets(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	minWinRate, ok := queryFloat(w, r, "minWinRate")
+	if !ok {
+		return
+	}
+	maxWinRate, ok := queryFloat(w, r, "maxWinRate")
+	if !ok {
+		return
+	}
+	minPnL, ok := queryFloat(w, r, "minRealizedPnl")
+	if !ok {
+		return
+	}
+	minOpen, ok := queryInt(w, r, "minOpenPositions", 0)
+	if !ok {
+		return
+	}
+	tags := splitList(q.Get("tags"))
+	excluded := splitList(q.Get("excludeTags"))
+
+	s.mu.Lock()
+	var matched []ramaris.Wallet
+	for _, wl := range s.wallets {
+		switch {
+		case minWinRate != nil && (wl.WinRate == nil || *wl.WinRate < *minWinRate),
+			maxWinRate != nil && (wl.WinRate == nil || *wl.WinRate > *maxWinRate),
+			minPnL != nil && (wl.RealizedPnL == nil || *wl.RealizedPnL < *minPnL),
+			wl.Stats.OpenPositions < minOpen,
+			!hasAll(wl.Tags, tags),
+			hasAny(wl.Tags, excluded):
+			continue
+		}
+		matched = append(matched, wl)
+	}
+	s.mu.Unlock()
+
+	var key func(ramaris.Wallet) float64
+	switch ramaris.WalletSortField(q.Get("sortBy")) {
+	case "":
+	case ramaris.WalletSortRealizedPnL:
+		key = func(wl ramaris.Wallet) float64 { return derefFloat(wl.RealizedPnL) }
+	case ramaris.WalletSortWinRate:
+		key = func(wl ramaris.Wallet) float64 { return derefFloat(wl.WinRate) }
+	case ramaris.WalletSortTotalSwaps:
+		key = func(wl ramaris.Wallet) float64 { return float64(wl.Stats.TotalSwaps) }
+	case ramaris.WalletSortCreatedAt:
+		key = func(wl ramaris.Wallet) float64 { return unixTime(&wl.CreatedAt) }
+	default:
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "unsupported sortBy")
+		return
+	}
+	if !sortBy(w, q.Get("sortOrder"), matched, key) {
+		return
+	}
+
+	items := make([]ramaris.WalletListItem, len(matched))
+	for i, wl := range matched {
+		items[i] = listItem(wl)
+	}
+	writePage(w, r, items)
+}
+
+// listItem returns the list view of a wallet.
+func listItem(wl ramaris.Wallet) ramaris.WalletListItem {
+	return ramaris.WalletListItem{
+		ID:          wl.ID,
+		WinRate:     wl.WinRate,
+		RealizedPnL: wl.RealizedPnL,
+		CreatedAt:   wl.CreatedAt,
+		Stats: ramaris.WalletStats{
+			TotalSwaps:    wl.Stats.TotalSwaps,
+			OpenPositions: wl.Stats.OpenPositions,
+		},
+		Tags: wl.Tags,
+	}
+}
+
+func (s *Server) handleFollowWallet(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "wallet id must be an integer")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, fw := range s.following {
+		if fw.ID == id {
+			writeData(w, fw)
+			return
+		}
+	}
+	i := slices.IndexFunc(s.wallets, func(wl ramaris.Wallet) bool { return wl.ID == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "wallet not found")
+		return
+	}
+	s.wallets[i].Stats.Followers++
+	if s.profile != nil {
+		s.profile.Stats.WalletsFollowed++
+	}
+	fw := ramaris.FollowedWallet{WalletListItem: listItem(s.wallets[i]), FollowedAt: s.now().UTC()}
+	s.following = append(s.following, fw)
+	writeJSON(w, http.StatusCreated, map[string]any{"data": fw})
+}
+
+func (s *Server) handleUnfollowWallet(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "wallet id must be an integer")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.following, func(fw ramaris.FollowedWallet) bool { return fw.ID == id })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "wallet not followed")
+		return
+	}
+	s.following = slices.Delete(s.following, i, i+1)
+	if j := slices.IndexFunc(s.wallets, func(wl ramaris.Wallet) bool { return wl.ID == id }); j >= 0 {
+		s.wallets[j].Stats.Followers--
+	}
+	if s.profile != nil {
+		s.profile.Stats.WalletsFollowed--
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListFollowedWallets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	items := slices.Clone(s.following)
+	s.mu.Unlock()
+	writePage(w, r, items)
+}
+
+func (s *Server) handleGetWallet(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "wallet id must be an integer")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, wl := range s.wallets {
+		if wl.ID == id {
+			writeData(w, wl)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "wallet not found")
+}
+
+func (s *Server) handleListWalletSwaps(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "wallet id must be an integer")
+		return
+	}
+	limit, ok := queryInt(w, r, "limit", defaultPageSize)
+	if !ok {
+		return
+	}
+	limit = min(limit, maxPageSize)
+	since, ok := queryTime(w, r, "since")
+	if !ok {
+		return
+	}
+	until, ok := queryTime(w, r, "until")
+	if !ok {
+		return
+	}
+	after, ok := decodeCursor(w, r.URL.Query().Get("cursor"))
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	if !slices.ContainsFunc(s.wallets, func(wl ramaris.Wallet) bool { return wl.ID == id }) {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "wallet not found")
+		return
+	}
+	swaps := slices.Clone(s.swaps[id])
+	s.mu.Unlock()
+
+	slices.SortFunc(swaps, func(a, b ramaris.Swap) int { return -compareSwaps(a, b) })
+
+	page := []ramaris.Swap{}
+	hasMore := false
+	for _, sw := range swaps {
+		if (after != nil && compareSwaps(sw, *after) >= 0) ||
+			(!since.IsZero() && sw.Timestamp.Before(since)) ||
+			(!until.IsZero() && sw.Timestamp.After(until)) {
+			continue
+		}
+		if len(page) == limit {
+			hasMore = true
+			break
+		}
+		page = append(page, sw)
+	}
+
+	next := ""
+	if hasMore {
+		next = encodeCursor(page[len(page)-1])
+	}
+	writeJSON(w, http.StatusOK, ramaris.CursorResponse[ramaris.Swap]{
+		Data:       page,
+		Pagination: ramaris.CursorPagination{NextCursor: next, HasMore: hasMore},
+	})
+}
+
+func (s *Server) handleListWalletPositions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "wallet id must be an integer")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.ContainsFunc(s.wallets, func(wl ramaris.Wallet) bool { return wl.ID == id }) {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "wallet not found")
+		return
+	}
+	writeData(w, append([]ramaris.Position{}, s.positions[id]...))
+}
+
+// compareSwaps orders swaps chronologically by block number and log index.
+func compareSwaps(a, b ramaris.Swap) int {
+	if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.LogIndex, b.LogIndex)
+}
+
+// encodeCursor returns an opaque cursor pointing just past sw.
+func encodeCursor(sw ramaris.Swap) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", sw.BlockNumber, sw.LogIndex)))
+}
+
+// decodeCursor parses a cursor from encodeCursor into the swap position it
+// points past. An empty cursor yields nil.
+func decodeCursor(w http.ResponseWriter, cursor string) (*ramaris.Swap, bool) {
+	if cursor == "" {
+		return nil, true
+	}
+	var pos ramaris.Swap
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		_, err = fmt.Sscanf(string(raw), "%d:%d", &pos.BlockNumber, &pos.LogIndex)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid cursor")
+		return nil, false
+	}
+	return &pos, true
+}
+
+func (s *Server) handleGetProfile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.profile == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "profile not found")
+		return
+	}
+	writeData(w, s.profile)
+}
+
+func (s *Server) handleGetSubscription(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscription == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "subscription not found")
+		return
+	}
+	writeData(w, s.subscription)
+}
+
+// writePage writes the page of items selected by the page and pageSize
+// query parameters.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	page, ok := queryInt(w, r, "page", 1)
+	if !ok {
+		return
+	}
+	pageSize, ok := queryInt(w, r, "pageSize", defaultPageSize)
+	if !ok {
+		return
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	start := min((page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+	writeJSON(w, http.StatusOK, ramaris.ListResponse[T]{
+		Data: append(make([]T, 0, end-start), items[start:end]...),
+		Pagination: ramaris.Pagination{
+			Page:       page,
+			PageSize:   pageSize,
+			TotalItems: len(items),
+			TotalPages: (len(items) + pageSize - 1) / pageSize,
+		},
+	})
+}
+
+// queryInt parses a positive integer query parameter, writing a validation
+// error and reporting false if it is malformed.
+func queryInt(w http.ResponseWriter, r *http.Request, name string, def int) (int, bool) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("%s must be a positive integer", name))
+		return 0, false
+	}
+	return n, true
+}
+
+// queryFloat parses an optional float query parameter, writing a validation
+// error and reporting false if it is malformed.
+func queryFloat(w http.ResponseWriter, r *http.Request, name string) (*float64, bool) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, true
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("%s must be a number", name))
+		return nil, false
+	}
+	return &f, true
+}
+
+// queryTime parses an optional RFC 3339 query parameter, writing a
+// validation error and reporting false if it is malformed.
+func queryTime(w http.ResponseWriter, r *http.Request, name string) (time.Time, bool) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, true
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("%s must be an RFC 3339 timestamp", name))
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// sortBy stably sorts items by key in the given order ("asc" by default).
+// A nil key leaves items in insertion order.
+func sortBy[T any](w http.ResponseWriter, order string, items []T, key func(T) float64) bool {
+	if order != "" && order != string(ramaris.SortAsc) && order != string(ramaris.SortDesc) {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "sortOrder must be asc or desc")
+		return false
+	}
+	if key == nil {
+		return true
+	}
+	desc := order == string(ramaris.SortDesc)
+	slices.SortStableFunc(items, func(a, b T) int {
+		c := cmp.Compare(key(a), key(b))
+		if desc {
+			return -c
+		}
+		return c
+	})
+	return true
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+func hasAll(have, want []string) bool {
+	for _, t := range want {
+		if !slices.Contains(have, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAny(have, exclude []string) bool {
+	for _, t := range exclude {
+		if slices.Contains(have, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// derefFloat returns *f, sorting nulls before every value.
+func derefFloat(f *float64) float64 {
+	if f == nil {
+		return math.Inf(-1)
+	}
+	return *f
+}
+
+func unixTime(t *time.Time) float64 {
+	if t == nil {
+		return math.Inf(-1)
+	}
+	return float64(t.UnixNano())
+}
+
+// readJSON decodes the request body into v, writing a validation error and
+// reporting false if it is malformed.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		return false
+	}
+	return true
+}
+
+// writeValidationError writes a *ramaris.ValidationError as a 400, with its
+// fields as the envelope's details.
+func writeValidationError(w http.ResponseWriter, err error) {
+	vErr, ok := err.(*ramaris.ValidationError)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusBadRequest, map[string]any{
+		"error": map[string]any{
+			"code":    "VALIDATION_ERROR",
+			"message": "invalid request",
+			"details": vErr.Fields,
+		},
+	})
+}
+
+func writeData(w http.ResponseWriter, v any) {
+	writeJSON(w, http.StatusOK, map[string]any{"data": v})
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]any{"code": code, "message": message},
+	})
+}
+
+func writeRateLimited(w http.ResponseWriter, retryAfter int) {
+	writeJSON(w, http.StatusTooManyRequests, map[string]any{
+		"error": map[string]any{
+			"code":       "RATE_LIMITED",
+			"message":    "rate limit exceeded",
+			"retryAfter": retryAfter,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// codeForStatus returns the API error code used for an HTTP status.
+func codeForStatus(status int) string {
+	switch {
+	case status == http.StatusBadRequest:
+		return "VALIDATION_ERROR"
+	case status == http.StatusUnauthorized:
+		return "UNAUTHORIZED"
+	case status == http.StatusForbidden:
+		return "FORBIDDEN"
+	case status == http.StatusNotFound:
+		return "NOT_FOUND"
+	case status >= 500:
+		return "INTERNAL_ERROR"
+	default:
+		return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+	}
+}
@@ -0,0 +1,751 @@
+package ramaristest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ramaris "github.com/ramaris-app/go-sdk"
+	"github.com/ramaris-app/go-sdk/ramaristest"
+)
+
+func seedStrategies(srv *ramaristest.Server, n int) {
+	for i := 1; i <= n; i++ {
+		srv.AddStrategy(ramaris.Strategy{
+			ID:        i,
+			ShareID:   fmt.Sprintf("s%d", i),
+			Name:      fmt.Sprintf("Strategy %d", i),
+			CreatedAt: time.Date(2025, 1, i, 0, 0, 0, 0, time.UTC),
+			Status:    "ACTIVE",
+			Stats:     ramaris.StrategyDetailStats{WalletsTracked: i},
+		})
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	seedStrategies(srv, 5)
+	c := srv.Client()
+
+	resp, err := c.ListStrategies(context.Background(), &ramaris.ListOptions{Page: 2, PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListStrategies() error: %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Data[0].ShareID != "s3" {
+		t.Errorf("Data = %+v, want s3, s4", resp.Data)
+	}
+	want := ramaris.Pagination{Page: 2, PageSize: 2, TotalItems: 5, TotalPages: 3}
+	if resp.Pagination != want {
+		t.Errorf("Pagination = %+v, want %+v", resp.Pagination, want)
+	}
+
+	n := 0
+	for _, err := range c.Strategies(context.Background(), &ramaris.ListOptions{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("Strategies() error: %v", err)
+		}
+		n++
+	}
+	if n != 5 {
+		t.Errorf("iterated %d strategies, want 5", n)
+	}
+}
+
+func TestServer_GetAndNotFound(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	seedStrategies(srv, 1)
+	srv.AddWallet(ramaris.Wallet{ID: 456, Status: "ACTIVE"})
+	c := srv.Client()
+	ctx := context.Background()
+
+	if s, err := c.GetStrategy(ctx, "s1"); err != nil || s.Name != "Strategy 1" {
+		t.Errorf("GetStrategy() = %v, %v", s, err)
+	}
+	if w, err := c.GetWallet(ctx, 456); err != nil || w.Status != "ACTIVE" {
+		t.Errorf("GetWallet() = %v, %v", w, err)
+	}
+
+	_, err := c.GetStrategy(ctx, "missing")
+	var apiErr *ramaris.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "NOT_FOUND" || apiErr.StatusCode != 404 {
+		t.Errorf("GetStrategy(missing) error = %v, want 404 NOT_FOUND", err)
+	}
+}
+
+func TestServer_UserEndpoints(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	nick := "alice"
+	srv.SetProfile(ramaris.UserProfile{ID: "u1", Nickname: &nick, Email: "a@b.com"})
+	srv.SetSubscription(ramaris.Subscription{Tier: "PRO", Status: "active"})
+	srv.AddWatchlist(ramaris.WatchlistStrategy{ShareID: "w1", Name: "Watched"})
+	c := srv.Client()
+	ctx := context.Background()
+
+	if p, err := c.GetProfile(ctx); err != nil || p.Email != "a@b.com" {
+		t.Errorf("GetProfile() = %v, %v", p, err)
+	}
+	if s, err := c.GetSubscription(ctx); err != nil || s.Tier != "PRO" {
+		t.Errorf("GetSubscription() = %v, %v", s, err)
+	}
+	if wl, err := c.ListWatchlist(ctx, nil); err != nil || len(wl.Data) != 1 {
+		t.Errorf("ListWatchlist() = %v, %v", wl, err)
+	}
+	if h, err := c.Health(ctx); err != nil || h.Status != "ok" {
+		t.Errorf("Health() = %v, %v", h, err)
+	}
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	c := ramaris.NewClient("rms_wrong", ramaris.WithBaseURL(srv.URL))
+
+	_, err := c.Health(context.Background())
+	var apiErr *ramaris.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "UNAUTHORIZED" {
+		t.Errorf("Health() error = %v, want UNAUTHORIZED", err)
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	srv := ramaristest.NewServer(ramaristest.WithRateLimit(2, time.Minute))
+	defer srv.Close()
+	c := srv.Client()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Health(ctx); err != nil {
+			t.Fatalf("Health() #%d error: %v", i, err)
+		}
+	}
+	if rl := c.RateLimit(); rl == nil || rl.Limit != 2 || rl.Remaining != 0 {
+		t.Errorf("RateLimit() = %+v, want limit 2, remaining 0", rl)
+	}
+
+	_, err := c.Health(ctx)
+	var rlErr *ramaris.RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.RetryAfter <= 0 {
+		t.Errorf("Health() error = %v, want *RateLimitError with RetryAfter", err)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	seedStrategies(srv, 1)
+	c := srv.Client(ramaris.WithRetryPolicy(ramaris.ConstantBackoff{MaxRetries: 2, Interval: time.Millisecond}))
+	ctx := context.Background()
+
+	// Two 503s are absorbed by retries.
+	srv.InjectFault(ramaristest.Fault{Path: "/strategies/s1", Status: 503, Times: 2})
+	if _, err := c.GetStrategy(ctx, "s1"); err != nil {
+		t.Fatalf("GetStrategy() error: %v", err)
+	}
+	if n := srv.Requests(); n != 3 {
+		t.Errorf("Requests() = %d, want 3", n)
+	}
+
+	srv.InjectFault(ramaristest.Fault{Status: 429, RetryAfter: 7, Times: 1})
+	_, err := c.Health(ctx)
+	var rlErr *ramaris.RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.RetryAfter != 7 {
+		t.Errorf("Health() error = %v, want RetryAfter 7", err)
+	}
+
+	srv.InjectFault(ramaristest.Fault{Latency: 200 * time.Millisecond})
+	tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Health(tctx); err == nil {
+		t.Error("Health() with latency error = nil, want timeout")
+	}
+
+	srv.ClearFaults()
+	if _, err := c.Health(ctx); err != nil {
+		t.Errorf("Health() after ClearFaults error: %v", err)
+	}
+}
+
+func TestServer_StrategyFilters(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	desc := "follows whales on Base"
+	srv.AddStrategy(ramaris.Strategy{ShareID: "a", Name: "Alpha", ROIPercent: ramaris.Float64(5), Tags: []string{"base"}, Creator: ramaris.StrategyCreator{Nickname: "alice"}})
+	srv.AddStrategy(ramaris.Strategy{ShareID: "b", Name: "Beta", ROIPercent: ramaris.Float64(40), Tags: []string{"base", "defi"}, Creator: ramaris.StrategyCreator{Nickname: "bob"}, Description: &desc})
+	srv.AddStrategy(ramaris.Strategy{ShareID: "c", Name: "Gamma", ROIPercent: ramaris.Float64(20), Tags: []string{"defi"}, Creator: ramaris.StrategyCreator{Nickname: "alice"}})
+	srv.AddStrategy(ramaris.Strategy{ShareID: "d", Name: "Delta"})
+	c := srv.Client()
+
+	tests := []struct {
+		name string
+		opts ramaris.StrategyListOptions
+		want string
+	}{
+		{"min roi", ramaris.StrategyListOptions{MinROI: ramaris.Float64(10)}, "bc"},
+		{"tags", ramaris.StrategyListOptions{Tags: []string{"base", "defi"}}, "b"},
+		{"creator", ramaris.StrategyListOptions{Creator: "alice"}, "ac"},
+		{"search", ramaris.StrategyListOptions{Search: "WHALES"}, "b"},
+		{"sort roi desc", ramaris.StrategyListOptions{SortBy: ramaris.StrategySortROI, SortOrder: ramaris.SortDesc}, "bcad"},
+		{"sort roi asc", ramaris.StrategyListOptions{SortBy: ramaris.StrategySortROI}, "dacb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := c.FilterStrategies(context.Background(), &tt.opts)
+			if err != nil {
+				t.Fatalf("FilterStrategies() error: %v", err)
+			}
+			got := ""
+			for _, s := range resp.Data {
+				got += s.ShareID
+			}
+			if got != tt.want {
+				t.Errorf("share IDs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	_, err := c.FilterStrategies(context.Background(), &ramaris.StrategyListOptions{SortBy: "bogus"})
+	var apiErr *ramaris.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "VALIDATION_ERROR" {
+		t.Errorf("FilterStrategies(bogus sort) error = %v, want VALIDATION_ERROR", err)
+	}
+}
+
+func TestServer_WalletFilters(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	srv.AddWallet(ramaris.Wallet{ID: 1, WinRate: ramaris.Float64(0.4), RealizedPnL: ramaris.Float64(100), Tags: []string{"whale"}, Stats: ramaris.WalletDetailStats{TotalSwaps: 10, OpenPositions: 1}})
+	srv.AddWallet(ramaris.Wallet{ID: 2, WinRate: ramaris.Float64(0.7), RealizedPnL: ramaris.Float64(5000), Tags: []string{"whale", "bot"}, Stats: ramaris.WalletDetailStats{TotalSwaps: 500, OpenPositions: 4}})
+	srv.AddWallet(ramaris.Wallet{ID: 3, WinRate: ramaris.Float64(0.9), RealizedPnL: ramaris.Float64(-20), Stats: ramaris.WalletDetailStats{TotalSwaps: 30, OpenPositions: 2}})
+	c := srv.Client()
+
+	tests := []struct {
+		name string
+		opts ramaris.WalletListOptions
+		want []int
+	}{
+		{"win rate range", ramaris.WalletListOptions{MinWinRate: ramaris.Float64(0.5), MaxWinRate: ramaris.Float64(0.8)}, []int{2}},
+		{"min pnl", ramaris.WalletListOptions{MinRealizedPnL: ramaris.Float64(0)}, []int{1, 2}},
+		{"tags", ramaris.WalletListOptions{Tags: []string{"whale"}, ExcludeTags: []string{"bot"}}, []int{1}},
+		{"open positions", ramaris.WalletListOptions{MinOpenPositions: 2}, []int{2, 3}},
+		{"sort swaps desc", ramaris.WalletListOptions{SortBy: ramaris.WalletSortTotalSwaps, SortOrder: ramaris.SortDesc}, []int{2, 3, 1}},
+		{"sort win rate", ramaris.WalletListOptions{SortBy: ramaris.WalletSortWinRate}, []int{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := c.FilterWallets(context.Background(), &tt.opts)
+			if err != nil {
+				t.Fatalf("FilterWallets() error: %v", err)
+			}
+			var got []int
+			for _, w := range resp.Data {
+				got = append(got, w.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("IDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_WalletSwapsCursor(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	srv.AddWallet(ramaris.Wallet{ID: 7})
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 5; i++ {
+		srv.AddSwaps(7, ramaris.Swap{
+			TxHash:      fmt.Sprintf("0x%d", i),
+			BlockNumber: uint64(100 + i),
+			Timestamp:   base.Add(time.Duration(i) * time.Hour),
+			AmountIn:    "1",
+		})
+	}
+	c := srv.Client()
+	ctx := context.Background()
+
+	first, err := c.ListWalletSwaps(ctx, 7, &ramaris.SwapListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListWalletSwaps() error: %v", err)
+	}
+	if len(first.Data) != 2 || first.Data[0].TxHash != "0x5" || !first.Pagination.HasMore {
+		t.Fatalf("first page = %+v", first)
+	}
+
+	// A new swap arriving between pages must not shift the next page.
+	srv.AddSwaps(7, ramaris.Swap{TxHash: "0x6", BlockNumber: 106, Timestamp: base.Add(6 * time.Hour)})
+
+	var rest []string
+	for s, err := range c.WalletSwaps(ctx, 7, &ramaris.SwapListOptions{Limit: 2, Cursor: first.Pagination.NextCursor}) {
+		if err != nil {
+			t.Fatalf("WalletSwaps() error: %v", err)
+		}
+		rest = append(rest, s.TxHash)
+	}
+	if fmt.Sprint(rest) != "[0x3 0x2 0x1]" {
+		t.Errorf("remaining swaps = %v, want [0x3 0x2 0x1]", rest)
+	}
+
+	ranged, err := c.ListWalletSwaps(ctx, 7, &ramaris.SwapListOptions{
+		Since: base.Add(2 * time.Hour),
+		Until: base.Add(4 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ListWalletSwaps(range) error: %v", err)
+	}
+	if len(ranged.Data) != 3 || ranged.Pagination.HasMore {
+		t.Errorf("ranged = %+v, want 3 swaps and no more", ranged)
+	}
+
+	_, err = c.ListWalletSwaps(ctx, 99, nil)
+	var apiErr *ramaris.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("ListWalletSwaps(unknown wallet) error = %v, want 404", err)
+	}
+}
+
+func TestServer_WalletPositions(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	srv.AddWallet(ramaris.Wallet{ID: 7})
+	c := srv.Client()
+	ctx := context.Background()
+
+	positions, err := c.ListWalletPositions(ctx, 7)
+	if err != nil || positions == nil || len(positions) != 0 {
+		t.Fatalf("ListWalletPositions() = %v, %v, want empty slice", positions, err)
+	}
+
+	srv.SetPositions(7, ramaris.Position{Symbol: "DEGEN", Size: "1000000000000.000000000000000001"})
+	positions, err = c.ListWalletPositions(ctx, 7)
+	if err != nil {
+		t.Fatalf("ListWalletPositions() error: %v", err)
+	}
+	if len(positions) != 1 || positions[0].Size != "1000000000000.000000000000000001" {
+		t.Errorf("positions = %+v", positions)
+	}
+}
+
+func TestServer_StrategyWallets(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	seedStrategies(srv, 1)
+	for i := 1; i <= 3; i++ {
+		srv.AddStrategyWallets("s1", ramaris.StrategyWallet{
+			WalletListItem: ramaris.WalletListItem{ID: i},
+			Weight:         1.0 / 3,
+		})
+	}
+	c := srv.Client()
+	ctx := context.Background()
+
+	var ids []int
+	for sw, err := range c.StrategyWallets(ctx, "s1", &ramaris.ListOptions{PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("StrategyWallets() error: %v", err)
+		}
+		ids = append(ids, sw.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("IDs = %v, want [1 2 3]", ids)
+	}
+
+	_, err := c.ListStrategyWallets(ctx, "missing", nil)
+	var apiErr *ramaris.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("ListStrategyWallets(missing) error = %v, want 404", err)
+	}
+}
+
+func TestServer_Notifications(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	seedStrategies(srv, 2)
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	srv.AddNotifications(
+		ramaris.Notification{ID: "a", Type: ramaris.NotificationSwapDetected, StrategyShareID: "s1", CreatedAt: day(1), Read: true},
+		ramaris.Notification{ID: "b", Type: ramaris.NotificationPositionOpened, StrategyShareID: "s1", CreatedAt: day(3)},
+		ramaris.Notification{ID: "c", Type: ramaris.NotificationThresholdCrossed, StrategyShareID: "s2", CreatedAt: day(2)},
+		ramaris.Notification{ID: "d", Type: ramaris.NotificationPositionClosed, StrategyShareID: "s1", CreatedAt: day(4)},
+	)
+	c := srv.Client()
+	ctx := context.Background()
+
+	ids := func(t *testing.T, opts *ramaris.NotificationListOptions, shareID string) string {
+		t.Helper()
+		var got []string
+		seq := c.Notifications(ctx, opts)
+		if shareID != "" {
+			seq = c.StrategyNotifications(ctx, shareID, opts)
+		}
+		for n, err := range seq {
+			if err != nil {
+				t.Fatalf("notifications error: %v", err)
+			}
+			got = append(got, n.ID)
+		}
+		return fmt.Sprint(got)
+	}
+
+	tests := []struct {
+		name    string
+		shareID string
+		opts    *ramaris.NotificationListOptions
+		want    string
+	}{
+		{"all", "", nil, "[d b c a]"},
+		{"paged", "", &ramaris.NotificationListOptions{ListOptions: ramaris.ListOptions{PageSize: 1}}, "[d b c a]"},
+		{"strategy", "s1", nil, "[d b a]"},
+		{"unread", "s1", &ramaris.NotificationListOptions{Read: ramaris.Bool(false)}, "[d b]"},
+		{"time range", "", &ramaris.NotificationListOptions{Since: day(2), Until: day(3)}, "[b c]"},
+		{"types", "", &ramaris.NotificationListOptions{Types: []ramaris.NotificationType{ramaris.NotificationPositionOpened, ramaris.NotificationPositionClosed}}, "[d b]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(t, tt.opts, tt.shareID); got != tt.want {
+				t.Errorf("IDs = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	_, err := c.ListStrategyNotifications(ctx, "missing", nil)
+	var apiErr *ramaris.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("ListStrategyNotifications(missing) error = %v, want 404", err)
+	}
+}
+
+func TestServer_WatchlistMutations(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	seedStrategies(srv, 2)
+	c := srv.Client()
+	ctx := context.Background()
+
+	for range 2 {
+		ws, err := c.AddToWatchlist(ctx, "s2")
+		if err != nil {
+			t.Fatalf("AddToWatchlist() error: %v", err)
+		}
+		if ws.Name != "Strategy 2" || ws.CopiedAt.IsZero() {
+			t.Errorf("entry = %+v", ws)
+		}
+	}
+	resp, err := c.ListWatchlist(ctx, nil)
+	if err != nil || len(resp.Data) != 1 {
+		t.Fatalf("ListWatchlist() = %v, %v, want one entry", resp, err)
+	}
+
+	if err := c.RemoveFromWatchlist(ctx, "s2"); err != nil {
+		t.Fatalf("RemoveFromWatchlist() error: %v", err)
+	}
+	resp, err = c.ListWatchlist(ctx, nil)
+	if err != nil || len(resp.Data) != 0 {
+		t.Fatalf("ListWatchlist() = %v, %v, want empty", resp, err)
+	}
+
+	var apiErr *ramaris.Error
+	if err := c.RemoveFromWatchlist(ctx, "s2"); !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("RemoveFromWatchlist(removed) error = %v, want 404", err)
+	}
+	if _, err := c.AddToWatchlist(ctx, "missing"); !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("AddToWatchlist(missing) error = %v, want 404", err)
+	}
+}
+
+func TestServer_IdempotencyKeyReplay(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	seedStrategies(srv, 1)
+	c := srv.Client()
+
+	add := func(key string) int {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/strategies/me/watchlist", strings.NewReader(`{"shareId":"s1"}`))
+		req.Header.Set("Authorization", "Bearer "+ramaristest.DefaultAPIKey)
+		req.Header.Set("Idempotency-Key", key)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST error: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if got := add("k1"); got != http.StatusCreated {
+		t.Fatalf("first POST status = %d, want 201", got)
+	}
+	if err := c.RemoveFromWatchlist(context.Background(), "s1"); err != nil {
+		t.Fatalf("RemoveFromWatchlist() error: %v", err)
+	}
+	// A repeat with the same key is not applied again.
+	if got := add("k1"); got != http.StatusCreated {
+		t.Errorf("replayed POST status = %d, want 201", got)
+	}
+	resp, err := c.ListWatchlist(context.Background(), nil)
+	if err != nil || len(resp.Data) != 0 {
+		t.Fatalf("ListWatchlist() = %v, %v, want empty after replay", resp, err)
+	}
+	if got := add("k2"); got != http.StatusCreated {
+		t.Errorf("new key POST status = %d, want 201", got)
+	}
+	if resp, _ := c.ListWatchlist(context.Background(), nil); len(resp.Data) != 1 {
+		t.Errorf("watchlist entries = %d, want 1", len(resp.Data))
+	}
+}
+
+func TestServer_IdempotencyKeyConcurrent(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+
+	create := func(body io.Reader) (string, error) {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/strategies", body)
+		req.Header.Set("Authorization", "Bearer "+ramaristest.DefaultAPIKey)
+		req.Header.Set("Idempotency-Key", "create-alpha")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		var created struct {
+			Data ramaris.Strategy `json:"data"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return "", err
+		}
+		if resp.StatusCode != http.StatusCreated {
+			return "", fmt.Errorf("status %d", resp.StatusCode)
+		}
+		return created.Data.ShareID, nil
+	}
+
+	// The first request stalls mid-body while a repeat with the same key
+	// arrives; the repeat must wait for it rather than create a second strategy.
+	pr, pw := io.Pipe()
+	type result struct {
+		shareID string
+		err     error
+	}
+	first := make(chan result, 1)
+	go func() {
+		id, err := create(pr)
+		first <- result{id, err}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	second := make(chan result, 1)
+	go func() {
+		id, err := create(strings.NewReader(`{"name":"Alpha"}`))
+		second <- result{id, err}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	pw.Write([]byte(`{"name":"Alpha"}`))
+	pw.Close()
+
+	r1, r2 := <-first, <-second
+	if r1.err != nil || r2.err != nil || r1.shareID != r2.shareID {
+		t.Errorf("responses = %+v, %+v; want the same created strategy", r1, r2)
+	}
+	resp, err := srv.Client().ListStrategies(context.Background(), nil)
+	if err != nil || resp.Pagination.TotalItems != 1 {
+		t.Errorf("ListStrategies() = %+v, %v; want one strategy", resp.Pagination, err)
+	}
+}
+
+func TestServer_StrategyCRUD(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	srv.AddWallet(ramaris.Wallet{ID: 456, Tags: []string{"whale"}})
+	c := srv.Client()
+	ctx := context.Background()
+
+	st, err := c.CreateStrategy(ctx, ramaris.CreateStrategyRequest{Name: "Alpha", Tags: []string{"base"}})
+	if err != nil {
+		t.Fatalf("CreateStrategy() error: %v", err)
+	}
+	if st.ShareID == "" || st.Status != ramaris.StrategyStatusActive || st.Description != nil {
+		t.Errorf("created = %+v", st)
+	}
+
+	st, err = c.UpdateStrategy(ctx, st.ShareID, ramaris.UpdateStrategyRequest{
+		Description: ramaris.String("whales on base"),
+		Tags:        []string{},
+		Status:      ramaris.String(ramaris.StrategyStatusPaused),
+	})
+	if err != nil {
+		t.Fatalf("UpdateStrategy() error: %v", err)
+	}
+	if st.Name != "Alpha" || st.Description == nil || *st.Description != "whales on base" || len(st.Tags) != 0 || st.Status != ramaris.StrategyStatusPaused {
+		t.Errorf("updated = %+v", st)
+	}
+
+	if _, err := c.AddWalletToStrategy(ctx, st.ShareID, ramaris.AddStrategyWalletRequest{WalletID: 456, Weight: ramaris.Float64(0.5)}); err != nil {
+		t.Fatalf("AddWalletToStrategy() error: %v", err)
+	}
+	var apiErr *ramaris.Error
+	if _, err := c.AddWalletToStrategy(ctx, st.ShareID, ramaris.AddStrategyWalletRequest{WalletID: 456}); !errors.As(err, &apiErr) || apiErr.StatusCode != 409 {
+		t.Errorf("AddWalletToStrategy(duplicate) error = %v, want 409", err)
+	}
+	if _, err := c.AddWalletToStrategy(ctx, st.ShareID, ramaris.AddStrategyWalletRequest{WalletID: 789}); !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("AddWalletToStrategy(missing wallet) error = %v, want 404", err)
+	}
+	members, err := c.ListStrategyWallets(ctx, st.ShareID, nil)
+	if err != nil || len(members.Data) != 1 || members.Data[0].Weight != 0.5 || members.Data[0].Tags[0] != "whale" {
+		t.Fatalf("ListStrategyWallets() = %+v, %v", members, err)
+	}
+
+	if err := c.RemoveWalletFromStrategy(ctx, st.ShareID, 456); err != nil {
+		t.Fatalf("RemoveWalletFromStrategy() error: %v", err)
+	}
+	if err := c.RemoveWalletFromStrategy(ctx, st.ShareID, 456); !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("RemoveWalletFromStrategy(removed) error = %v, want 404", err)
+	}
+
+	if err := c.DeleteStrategy(ctx, st.ShareID); err != nil {
+		t.Fatalf("DeleteStrategy() error: %v", err)
+	}
+	if _, err := c.GetStrategy(ctx, st.ShareID); !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("GetStrategy(deleted) error = %v, want 404", err)
+	}
+}
+
+func TestServer_StrategyValidation(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+
+	// Bypass client-side validation to check the server's own.
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/strategies", strings.NewReader(`{"name":"","status":"GONE"}`))
+	req.Header.Set("Authorization", "Bearer "+ramaristest.DefaultAPIKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", resp.StatusCode)
+	}
+	var body struct {
+		Error struct {
+			Details []ramaris.FieldError `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if d := body.Error.Details; len(d) != 2 || d[0].Field != "name" || d[1].Field != "status" {
+		t.Errorf("details = %+v, want name and status field errors", d)
+	}
+}
+
+func TestServer_FollowWallets(t *testing.T) {
+	srv := ramaristest.NewServer()
+	defer srv.Close()
+	srv.AddWallet(ramaris.Wallet{ID: 1})
+	srv.AddWallet(ramaris.Wallet{ID: 2})
+	srv.SetProfile(ramaris.UserProfile{ID: "u1"})
+	c := srv.Client()
+	ctx := context.Background()
+
+	for _, id := range []int{2, 1, 2} {
+		if _, err := c.FollowWallet(ctx, id); err != nil {
+			t.Fatalf("FollowWallet(%d) error: %v", id, err)
+		}
+	}
+	var ids []int
+	for fw, err := range c.FollowedWallets(ctx, &ramaris.ListOptions{PageSize: 1}) {
+		if err != nil {
+			t.Fatalf("FollowedWallets() error: %v", err)
+		}
+		if fw.FollowedAt.IsZero() {
+			t.Errorf("wallet %d FollowedAt is zero", fw.ID)
+		}
+		ids = append(ids, fw.ID)
+	}
+	if fmt.Sprint(ids) != "[2 1]" {
+		t.Errorf("IDs = %v, want [2 1]", ids)
+	}
+
+	w, err := c.GetWallet(ctx, 2)
+	if err != nil || w.Stats.Followers != 1 {
+		t.Errorf("GetWallet() followers = %+v, %v, want 1", w, err)
+	}
+	p, err := c.GetProfile(ctx)
+	if err != nil || p.Stats.WalletsFollowed != 2 {
+		t.Errorf("GetProfile() walletsFollowed = %+v, %v, want 2", p, err)
+	}
+
+	if err := c.UnfollowWallet(ctx, 2); err != nil {
+		t.Fatalf("UnfollowWallet() error: %v", err)
+	}
+	var apiErr *ramaris.Error
+	if err := c.UnfollowWallet(ctx, 2); !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("UnfollowWallet(unfollowed) error = %v, want 404", err)
+	}
+	if _, err := c.FollowWallet(ctx, 99); !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("FollowWallet(missing) error = %v, want 404", err)
+	}
+}
+
+func TestServer_Stream(t *testing.T) {
+	srv := ramaristest.NewServer(ramaristest.WithHeartbeat(10 * time.Millisecond))
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	next := func(s *ramaris.Stream) ramaris.StreamEvent {
+		t.Helper()
+		select {
+		case ev := <-s.Events():
+			return ev
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for an event: %v", s.Err())
+			return ramaris.StreamEvent{}
+		}
+	}
+
+	srv.Publish(ramaris.StreamEvent{Type: ramaris.StreamSwap, WalletID: 456, Swap: &ramaris.Swap{TxHash: "0x1"}})
+	s, err := srv.Client().Stream(ctx, &ramaris.StreamFilters{Wallets: []int{456}}, ramaris.WithHeartbeatTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+	if ev := next(s); ev.ID != "1" || ev.Swap == nil || ev.Swap.TxHash != "0x1" {
+		t.Errorf("backlog event = %+v, want swap 0x1", ev)
+	}
+
+	srv.Publish(
+		ramaris.StreamEvent{Type: ramaris.StreamSwap, WalletID: 789},
+		ramaris.StreamEvent{Type: ramaris.StreamPosition, WalletID: 456, Position: &ramaris.Position{Symbol: "WETH"}},
+	)
+	if ev := next(s); ev.ID != "3" || ev.Type != ramaris.StreamPosition {
+		t.Errorf("live event = %+v, want position 3 (789 filtered out)", ev)
+	}
+
+	// Outlasting the heartbeat timeout shows heartbeats keep the connection.
+	time.Sleep(100 * time.Millisecond)
+	before := srv.Requests()
+	srv.DisconnectStreams()
+	srv.Publish(ramaris.StreamEvent{Type: ramaris.StreamNotification, WalletID: 456, Notification: &ramaris.Notification{ID: "n1"}})
+	if ev := next(s); ev.ID != "4" || ev.Notification == nil {
+		t.Errorf("event after reconnect = %+v, want notification 4", ev)
+	}
+	if got := srv.Requests() - before; got != 1 {
+		t.Errorf("reconnects = %d, want 1", got)
+	}
+
+	resumed, err := srv.Client().Stream(ctx, &ramaris.StreamFilters{Types: []ramaris.StreamEventType{ramaris.StreamSwap}}, ramaris.WithLastEventID("1"))
+	if err != nil {
+		t.Fatalf("Stream(resume) error: %v", err)
+	}
+	if ev := next(resumed); ev.ID != "2" || ev.WalletID != 789 {
+		t.Errorf("resumed event = %+v, want swap 2", ev)
+	}
+}
@@ -0,0 +1,130 @@
+package ramaristest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	ramaris "github.com/ramaris-app/go-sdk"
+)
+
+// streamRetry is the reconnect delay streams ask clients to use, kept short
+// so that tests reconnect promptly.
+const streamRetry = 50 * time.Millisecond
+
+// Publish appends events to the live stream and pushes them to open
+// connections whose filters match. Events are numbered from 1 in publish
+// order, replacing any ID already set, so that clients can resume with
+// Last-Event-ID.
+func (s *Server) Publish(events ...ramaris.StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ev := range events {
+		ev.ID = strconv.Itoa(len(s.stream) + 1)
+		s.stream = append(s.stream, ev)
+	}
+	close(s.published)
+	s.published = make(chan struct{})
+}
+
+// DisconnectStreams closes every open stream connection, as a server restart
+// or network failure would. Clients are expected to reconnect.
+func (s *Server) DisconnectStreams() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	close(s.disconnect)
+	s.disconnect = make(chan struct{})
+}
+
+// handleStream serves the event stream, starting after the event named by
+// the Last-Event-ID header, if any, and then following Publish until the
+// client goes away.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	types := splitList(q.Get("types"))
+	strategies := splitList(q.Get("strategies"))
+	var wallets []int
+	for _, v := range splitList(q.Get("wallets")) {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "wallets must be a list of wallet IDs")
+			return
+		}
+		wallets = append(wallets, id)
+	}
+	match := func(ev ramaris.StreamEvent) bool {
+		if len(types) > 0 && !slices.Contains(types, string(ev.Type)) {
+			return false
+		}
+		if len(wallets) == 0 && len(strategies) == 0 {
+			return true
+		}
+		return slices.Contains(wallets, ev.WalletID) || slices.Contains(strategies, ev.StrategyShareID)
+	}
+
+	next := 0
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		n, err := strconv.Atoi(id)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid Last-Event-ID")
+			return
+		}
+		next = n
+	}
+
+	var heartbeat <-chan time.Time
+	if s.heartbeat > 0 {
+		ticker := time.NewTicker(s.heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	flusher := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+
+	s.mu.Lock()
+	disconnect := s.disconnect
+	s.mu.Unlock()
+	for {
+		s.mu.Lock()
+		if s.disconnect != disconnect {
+			// Disconnected while events were being published.
+			s.mu.Unlock()
+			return
+		}
+		var pending []ramaris.StreamEvent
+		if next < len(s.stream) {
+			pending = slices.Clone(s.stream[next:])
+			next = len(s.stream)
+		}
+		published := s.published
+		s.mu.Unlock()
+
+		for _, ev := range pending {
+			if !match(ev) {
+				continue
+			}
+			data, _ := json.Marshal(ev)
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data)
+		}
+		flusher.Flush()
+
+		select {
+		case <-published:
+		case <-heartbeat:
+			fmt.Fprint(w, ": ping\n\n")
+		case <-disconnect:
+			return
+		case <-s.closed:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ramaris
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimiter enables client-side throttling driven by the X-RateLimit-*
+// response headers. Once the remaining budget reaches zero, requests block
+// until the reported reset time (or until their context is done) instead of
+// being rejected by the API with a RateLimitError.
+func WithRateLimiter() Option {
+	return func(c *Client) { c.limiter = newRateLimiter() }
+}
+
+// rateLimiter is a token bucket whose size and refill time come from the API.
+// Tokens are reserved before a request is sent so that concurrent callers
+// cannot overshoot the budget between responses.
+type rateLimiter struct {
+	mu        sync.Mutex
+	known     bool
+	limit     int
+	remaining int
+	reset     time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{now: time.Now}
+}
+
+// wait reserves a token, blocking until the window resets if none are left.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.mu.Lock()
+		if !l.known || l.remaining > 0 {
+			if l.known {
+				l.remaining--
+			}
+			l.mu.Unlock()
+			return nil
+		}
+
+		d := l.reset.Sub(l.now())
+		if d <= 0 {
+			l.remaining = l.limit
+			l.mu.Unlock()
+			continue
+		}
+		l.mu.Unlock()
+
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// update records the budget reported by the API. Within the same window the
+// lower of the local and reported counts wins, since responses to concurrent
+// requests may arrive out of order. A limit of zero or less, as from a
+// malformed header, leaves the budget unknown so requests aren't throttled.
+func (l *rateLimiter) update(info RateLimitInfo) {
+	reset := time.Unix(int64(info.Reset), 0)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if info.Limit <= 0 {
+		l.known = false
+		return
+	}
+	if l.known && reset.Equal(l.reset) && l.remaining < info.Remaining {
+		return
+	}
+	l.known = true
+	l.limit = info.Limit
+	l.remaining = info.Remaining
+	l.reset = reset
+}
+
+// RateLimitRetry configures automatic retries of rate limited (HTTP 429)
+// requests. The zero value disables them, so a *RateLimitError is returned
+// to the caller immediately.
+type RateLimitRetry struct {
+	// MaxRetries is the maximum number of 429 retries per call.
+	MaxRetries int
+	// MaxWait caps a single RetryAfter wait. When the API asks for a longer
+	// wait, the *RateLimitError is returned instead. Zero means no cap.
+	MaxWait time.Duration
+}
+
+// WithRateLimitRetry makes the client sleep for RateLimitError.RetryAfter and
+// retry transparently, as long as the wait fits within r.MaxWait and the
+// context deadline.
+func WithRateLimitRetry(r RateLimitRetry) Option {
+	return func(c *Client) { c.rateLimitRetry = r }
+}
+
+// defaultRetryAfter is used when a 429 response does not say how long to wait.
+const defaultRetryAfter = time.Second
+
+// delay reports how long to wait before retrying a rate limited request, and
+// whether a retry should happen at all.
+func (r RateLimitRetry) delay(ctx context.Context, err *RateLimitError, retries int) (time.Duration, bool) {
+	if retries >= r.MaxRetries {
+		return 0, false
+	}
+
+	d := time.Duration(err.RetryAfter) * time.Second
+	if d <= 0 {
+		d = defaultRetryAfter
+	}
+	if r.MaxWait > 0 && d > r.MaxWait {
+		return 0, false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return 0, false
+	}
+	return d, true
+}
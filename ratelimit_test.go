@@ -0,0 +1,237 @@
+package ramaris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimiter(t *testing.T) {
+	c := NewClient("rms_key", WithRateLimiter())
+	if c.limiter == nil {
+		t.Error("limiter = nil, want non-nil")
+	}
+	if NewClient("rms_key").limiter != nil {
+		t.Error("limiter enabled by default, want opt-in")
+	}
+}
+
+func TestRateLimiter_UnknownBudgetDoesNotBlock(t *testing.T) {
+	l := newRateLimiter()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	for i := 0; i < 10; i++ {
+		if err := l.wait(ctx); err != nil {
+			t.Fatalf("wait() error: %v", err)
+		}
+	}
+}
+
+func TestRateLimiter_BlocksUntilReset(t *testing.T) {
+	l := newRateLimiter()
+	now := time.Unix(1700000000, 0)
+	l.now = func() time.Time { return now }
+	l.update(RateLimitInfo{Limit: 5, Remaining: 1, Reset: 1700000060})
+
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("wait() error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	now = now.Add(61 * time.Second)
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatalf("wait() after reset error: %v", err)
+	}
+	if l.remaining != 4 {
+		t.Errorf("remaining = %d, want 4", l.remaining)
+	}
+}
+
+func TestRateLimiter_ZeroLimitDoesNotBlock(t *testing.T) {
+	l := newRateLimiter()
+	now := time.Unix(1700000000, 0)
+	l.now = func() time.Time { return now }
+	l.update(RateLimitInfo{Limit: 5, Remaining: 0, Reset: 1700000060})
+	// A zero limit, as from a malformed header, after the window has passed.
+	l.update(RateLimitInfo{Limit: 0, Remaining: 0, Reset: 1699999999})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := l.wait(ctx); err != nil {
+		t.Fatalf("wait() error = %v, want no throttling without a known limit", err)
+	}
+}
+
+func TestRateLimiter_WaitChecksContext(t *testing.T) {
+	l := newRateLimiter()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRateLimiter_UpdateKeepsLowerCountInWindow(t *testing.T) {
+	l := newRateLimiter()
+	l.update(RateLimitInfo{Limit: 10, Remaining: 3, Reset: 100})
+	l.update(RateLimitInfo{Limit: 10, Remaining: 5, Reset: 100})
+	if l.remaining != 3 {
+		t.Errorf("remaining = %d, want 3", l.remaining)
+	}
+
+	l.update(RateLimitInfo{Limit: 10, Remaining: 9, Reset: 160})
+	if l.remaining != 9 {
+		t.Errorf("remaining after new window = %d, want 9", l.remaining)
+	}
+}
+
+func TestClient_RateLimiterWaitsForReset(t *testing.T) {
+	var calls atomic.Int32
+	// The header has whole seconds, so truncation can put a 1s reset less
+	// than a second away.
+	reset := time.Now().Add(2 * time.Second).Unix()
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	WithRateLimiter()(c)
+
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Health(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Health() error = %v, want context.DeadlineExceeded", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("calls = %d, want 1", n)
+	}
+}
+
+func TestClient_RateLimiterConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	reset := time.Now().Add(time.Hour).Unix()
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(5-n)))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	WithRateLimiter()(c)
+
+	// Seed the budget: 4 requests left in this window.
+	if _, err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var ok atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Health(ctx); err == nil {
+				ok.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := ok.Load(); n != 4 {
+		t.Errorf("successful requests = %d, want 4", n)
+	}
+}
+
+func TestClient_RateLimitRetry(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"code":"RATE_LIMITED","message":"too many requests","retryAfter":1}}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	WithRateLimitRetry(RateLimitRetry{MaxRetries: 2, MaxWait: 5 * time.Second})(c)
+
+	start := time.Now()
+	h, err := c.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+	if h.Status != "ok" {
+		t.Errorf("Status = %q, want %q", h.Status, "ok")
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("elapsed = %v, want >= 1s", elapsed)
+	}
+}
+
+func TestClient_RateLimitRetryLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		retry      RateLimitRetry
+		timeout    time.Duration
+		retryAfter int
+	}{
+		{"disabled", RateLimitRetry{}, 0, 1},
+		{"exceeds max wait", RateLimitRetry{MaxRetries: 3, MaxWait: time.Second}, 0, 30},
+		{"exceeds deadline", RateLimitRetry{MaxRetries: 3}, 500 * time.Millisecond, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.WriteHeader(http.StatusTooManyRequests)
+				fmt.Fprintf(w, `{"error":{"code":"RATE_LIMITED","message":"slow down","retryAfter":%d}}`, tt.retryAfter)
+			})
+			WithRateLimitRetry(tt.retry)(c)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			_, err := c.Health(ctx)
+			var rlErr *RateLimitError
+			if !errors.As(err, &rlErr) {
+				t.Fatalf("error = %v, want *RateLimitError", err)
+			}
+			if calls != 1 {
+				t.Errorf("calls = %d, want 1", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package ramaris
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Strategy statuses.
+const (
+	StrategyStatusActive   = "ACTIVE"
+	StrategyStatusPaused   = "PAUSED"
+	StrategyStatusArchived = "ARCHIVED"
+)
+
+// Limits enforced by the API and checked by Validate before sending.
+const (
+	MaxStrategyNameLength        = 100
+	MaxStrategyDescriptionLength = 1000
+	MaxStrategyTags              = 10
+	MaxTagLength                 = 32
+)
+
+// CreateStrategyRequest is the body of CreateStrategy.
+type CreateStrategyRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Status defaults to StrategyStatusActive.
+	Status string `json:"status,omitempty"`
+}
+
+// Validate checks r against the API's rules.
+func (r *CreateStrategyRequest) Validate() error {
+	var v validator
+	if strings.TrimSpace(r.Name) == "" {
+		v.add("name", "is required")
+	}
+	v.strategyFields(&r.Name, &r.Description, r.Tags, r.Status)
+	return v.err()
+}
+
+// UpdateStrategyRequest is the body of UpdateStrategy. Only non-nil fields
+// are changed; an empty non-nil Tags clears the strategy's tags.
+type UpdateStrategyRequest struct {
+	Name        *string
+	Description *string
+	Tags        []string
+	Status      *string
+}
+
+// Validate checks r against the API's rules.
+func (r *UpdateStrategyRequest) Validate() error {
+	var v validator
+	if r.Name == nil && r.Description == nil && r.Tags == nil && r.Status == nil {
+		v.add("", "no fields to update")
+	}
+	if r.Name != nil && strings.TrimSpace(*r.Name) == "" {
+		v.add("name", "must not be empty")
+	}
+	status := ""
+	if r.Status != nil {
+		if status = *r.Status; status == "" {
+			v.add("status", "must not be empty")
+		}
+	}
+	v.strategyFields(r.Name, r.Description, r.Tags, status)
+	return v.err()
+}
+
+// MarshalJSON omits unset fields, keeping an empty non-nil Tags.
+func (r UpdateStrategyRequest) MarshalJSON() ([]byte, error) {
+	body := struct {
+		Name        *string   `json:"name,omitempty"`
+		Description *string   `json:"description,omitempty"`
+		Tags        *[]string `json:"tags,omitempty"`
+		Status      *string   `json:"status,omitempty"`
+	}{Name: r.Name, Description: r.Description, Status: r.Status}
+	if r.Tags != nil {
+		body.Tags = &r.Tags
+	}
+	return json.Marshal(body)
+}
+
+// AddStrategyWalletRequest is the body of AddWalletToStrategy.
+type AddStrategyWalletRequest struct {
+	WalletID int `json:"walletId"`
+	// Weight is the wallet's share of the strategy, in (0, 1]. Nil lets the
+	// API weight wallets equally.
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// Validate checks r against the API's rules.
+func (r *AddStrategyWalletRequest) Validate() error {
+	var v validator
+	if r.WalletID <= 0 {
+		v.add("walletId", "must be positive")
+	}
+	if r.Weight != nil && (*r.Weight <= 0 || *r.Weight > 1) {
+		v.add("weight", "must be greater than 0 and at most 1")
+	}
+	return v.err()
+}
+
+// String returns a pointer to v, for optional fields such as
+// UpdateStrategyRequest.Name.
+func String(v string) *string {
+	return &v
+}
+
+// validator collects field errors.
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) add(field, msg string) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: msg})
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+// strategyFields checks the fields shared by strategy create and update
+// requests. Nil or empty values are skipped.
+func (v *validator) strategyFields(name, description *string, tags []string, status string) {
+	if name != nil && utf8.RuneCountInString(*name) > MaxStrategyNameLength {
+		v.add("name", fmt.Sprintf("must be at most %d characters", MaxStrategyNameLength))
+	}
+	if description != nil && utf8.RuneCountInString(*description) > MaxStrategyDescriptionLength {
+		v.add("description", fmt.Sprintf("must be at most %d characters", MaxStrategyDescriptionLength))
+	}
+	if len(tags) > MaxStrategyTags {
+		v.add("tags", fmt.Sprintf("must have at most %d entries", MaxStrategyTags))
+	}
+	for i, tag := range tags {
+		field := fmt.Sprintf("tags[%d]", i)
+		switch {
+		case strings.TrimSpace(tag) == "":
+			v.add(field, "must not be empty")
+		case utf8.RuneCountInString(tag) > MaxTagLength:
+			v.add(field, fmt.Sprintf("must be at most %d characters", MaxTagLength))
+		case strings.Contains(tag, ","):
+			v.add(field, "must not contain commas")
+		case slices.Contains(tags[:i], tag):
+			v.add(field, fmt.Sprintf("duplicates %q", tag))
+		}
+	}
+	switch status {
+	case "", StrategyStatusActive, StrategyStatusPaused, StrategyStatusArchived:
+	default:
+		v.add("status", fmt.Sprintf("must be %s, %s or %s", StrategyStatusActive, StrategyStatusPaused, StrategyStatusArchived))
+	}
+}
@@ -0,0 +1,95 @@
+package ramaris
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCreateStrategyRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    CreateStrategyRequest
+		fields []string
+	}{
+		{"valid", CreateStrategyRequest{Name: "Alpha", Tags: []string{"base", "defi"}, Status: StrategyStatusPaused}, nil},
+		{"missing name", CreateStrategyRequest{Name: "  "}, []string{"name"}},
+		{"long name", CreateStrategyRequest{Name: strings.Repeat("é", MaxStrategyNameLength+1)}, []string{"name"}},
+		{"long description", CreateStrategyRequest{Name: "A", Description: strings.Repeat("x", MaxStrategyDescriptionLength+1)}, []string{"description"}},
+		{"too many tags", CreateStrategyRequest{Name: "A", Tags: strings.Split("a,b,c,d,e,f,g,h,i,j,k", ",")}, []string{"tags"}},
+		{"bad tags", CreateStrategyRequest{Name: "A", Tags: []string{"", "a,b", "x", "x"}}, []string{"tags[0]", "tags[1]", "tags[3]"}},
+		{"bad status", CreateStrategyRequest{Name: "A", Status: "DELETED"}, []string{"status"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldErrors(t, tt.req.Validate(), tt.fields)
+		})
+	}
+}
+
+func TestUpdateStrategyRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    UpdateStrategyRequest
+		fields []string
+	}{
+		{"name only", UpdateStrategyRequest{Name: String("Beta")}, nil},
+		{"clear tags", UpdateStrategyRequest{Tags: []string{}}, nil},
+		{"empty", UpdateStrategyRequest{}, []string{""}},
+		{"empty name", UpdateStrategyRequest{Name: String("")}, []string{"name"}},
+		{"empty status", UpdateStrategyRequest{Status: String("")}, []string{"status"}},
+		{"bad status", UpdateStrategyRequest{Status: String("paused")}, []string{"status"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldErrors(t, tt.req.Validate(), tt.fields)
+		})
+	}
+}
+
+func TestUpdateStrategyRequest_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		req  UpdateStrategyRequest
+		want string
+	}{
+		{UpdateStrategyRequest{Name: String("Beta")}, `{"name":"Beta"}`},
+		{UpdateStrategyRequest{Description: String(""), Tags: []string{}}, `{"description":"","tags":[]}`},
+		{UpdateStrategyRequest{Tags: []string{"a"}, Status: String(StrategyStatusArchived)}, `{"tags":["a"],"status":"ARCHIVED"}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.req)
+		if err != nil {
+			t.Fatalf("Marshal() error: %v", err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Marshal() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestAddStrategyWalletRequest_Validate(t *testing.T) {
+	checkFieldErrors(t, (&AddStrategyWalletRequest{WalletID: 1, Weight: Float64(1)}).Validate(), nil)
+	checkFieldErrors(t, (&AddStrategyWalletRequest{WalletID: 0, Weight: Float64(0)}).Validate(), []string{"walletId", "weight"})
+}
+
+func checkFieldErrors(t *testing.T, err error, want []string) {
+	t.Helper()
+	if want == nil {
+		if err != nil {
+			t.Errorf("Validate() error = %v, want nil", err)
+		}
+		return
+	}
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("Validate() error = %v, want *ValidationError", err)
+	}
+	var got []string
+	for _, f := range vErr.Fields {
+		got = append(got, f.Field)
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("fields = %q, want %q (%v)", got, want, err)
+	}
+}
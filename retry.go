@@ -0,0 +1,104 @@
+package ramaris
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request is retried and how long to
+// wait first. Rate limited (HTTP 429) responses are handled separately by
+// WithRateLimitRetry and are never passed to a RetryPolicy, and neither are
+// failures of requests with non-idempotent methods (POST, PATCH) sent without
+// an idempotency key, which are not retried.
+type RetryPolicy interface {
+	// ShouldRetry reports whether to retry after a failed attempt. retries is
+	// the number of retries already made for this call. statusCode is the
+	// HTTP status, or 0 when the request failed at the transport level, in
+	// which case err is non-nil.
+	ShouldRetry(retries, statusCode int, err error) bool
+
+	// Delay returns how long to wait before the next retry.
+	Delay(retries int) time.Duration
+}
+
+// WithRetryPolicy replaces the default retry policy. Passing nil disables
+// retries of server and transport errors.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retryPolicy = p }
+}
+
+// DefaultRetryPolicy is used by clients created without WithRetryPolicy.
+var DefaultRetryPolicy RetryPolicy = ExponentialBackoff{
+	MaxRetries: 2,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   5 * time.Second,
+}
+
+// ExponentialBackoff retries 5xx responses and transient transport errors,
+// waiting a random duration between zero and BaseDelay*2^retries (capped at
+// MaxDelay) before each retry ("full jitter").
+type ExponentialBackoff struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// ShouldRetry implements RetryPolicy.
+func (b ExponentialBackoff) ShouldRetry(retries, statusCode int, err error) bool {
+	return retries < b.MaxRetries && isRetryable(statusCode, err)
+}
+
+// Delay implements RetryPolicy.
+func (b ExponentialBackoff) Delay(retries int) time.Duration {
+	d := b.BaseDelay
+	for i := 0; i < retries && (b.MaxDelay <= 0 || d < b.MaxDelay); i++ {
+		d *= 2
+	}
+	if b.MaxDelay > 0 && d > b.MaxDelay {
+		d = b.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d + 1)
+}
+
+// ConstantBackoff retries 5xx responses and transient transport errors,
+// waiting Interval before each retry.
+type ConstantBackoff struct {
+	MaxRetries int
+	Interval   time.Duration
+}
+
+// ShouldRetry implements RetryPolicy.
+func (b ConstantBackoff) ShouldRetry(retries, statusCode int, err error) bool {
+	return retries < b.MaxRetries && isRetryable(statusCode, err)
+}
+
+// Delay implements RetryPolicy.
+func (b ConstantBackoff) Delay(int) time.Duration {
+	return b.Interval
+}
+
+// isRetryable reports whether a failure is worth retrying: any 5xx response,
+// or a transport error such as a connection reset or refused connection.
+func isRetryable(statusCode int, err error) bool {
+	if err == nil {
+		return statusCode >= http.StatusInternalServerError
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
@@ -0,0 +1,204 @@
+package ramaris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff_ShouldRetry(t *testing.T) {
+	b := ExponentialBackoff{MaxRetries: 2, BaseDelay: time.Millisecond}
+
+	tests := []struct {
+		name       string
+		retries    int
+		statusCode int
+		err        error
+		want       bool
+	}{
+		{"500", 0, 500, nil, true},
+		{"503 last retry", 1, 503, nil, true},
+		{"exhausted", 2, 500, nil, false},
+		{"404", 0, 404, nil, false},
+		{"connection reset", 0, 0, &net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{"unexpected EOF", 0, 0, io.ErrUnexpectedEOF, true},
+		{"canceled", 0, 0, context.Canceled, false},
+		{"other", 0, 0, errors.New("unsupported protocol scheme"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.ShouldRetry(tt.retries, tt.statusCode, tt.err); got != tt.want {
+				t.Errorf("ShouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff_Delay(t *testing.T) {
+	b := ExponentialBackoff{MaxRetries: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for retries, ceiling := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		for i := 0; i < 50; i++ {
+			if d := b.Delay(retries); d < 0 || d > ceiling {
+				t.Fatalf("Delay(%d) = %v, want within [0, %v]", retries, d, ceiling)
+			}
+		}
+	}
+}
+
+func TestConstantBackoff(t *testing.T) {
+	b := ConstantBackoff{MaxRetries: 1, Interval: 250 * time.Millisecond}
+
+	if d := b.Delay(5); d != 250*time.Millisecond {
+		t.Errorf("Delay() = %v, want 250ms", d)
+	}
+	if !b.ShouldRetry(0, 502, nil) {
+		t.Error("ShouldRetry(0, 502) = false, want true")
+	}
+	if b.ShouldRetry(1, 502, nil) {
+		t.Error("ShouldRetry(1, 502) = true, want false")
+	}
+}
+
+func TestClient_RetryPolicyDisabled(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	WithRetryPolicy(nil)(c)
+
+	_, err := c.Health(context.Background())
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Fatalf("error = %v, want 503 *Error", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestClient_RetryPolicyConstant(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	WithRetryPolicy(ConstantBackoff{MaxRetries: 4, Interval: time.Millisecond})(c)
+
+	if _, err := c.Health(context.Background()); err == nil {
+		t.Fatal("Health() error = nil, want error")
+	}
+	if calls != 5 {
+		t.Errorf("calls = %d, want 5", calls)
+	}
+}
+
+func TestClient_RetryTransportError(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// Drop the connection without a response.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("Hijack() error: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	WithRetryPolicy(ConstantBackoff{MaxRetries: 1, Interval: time.Millisecond})(c)
+
+	h, err := c.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health() error: %v", err)
+	}
+	if h.Status != "ok" {
+		t.Errorf("Status = %q, want %q", h.Status, "ok")
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestClient_NoRetryForPostWithoutIdempotencyKey(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if key := r.Header.Get("Idempotency-Key"); key != "" {
+			t.Errorf("Idempotency-Key = %q, want none", key)
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	WithRetryPolicy(ConstantBackoff{MaxRetries: 3, Interval: time.Millisecond})(c)
+	WithoutIdempotencyKeys()(c)
+
+	_, err := c.AddToWatchlist(context.Background(), "abc")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 502 {
+		t.Fatalf("error = %v, want 502 *Error", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestClient_RetryDelete(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	WithRetryPolicy(ConstantBackoff{MaxRetries: 1, Interval: time.Millisecond})(c)
+
+	if err := c.RemoveFromWatchlist(context.Background(), "abc"); err != nil {
+		t.Fatalf("RemoveFromWatchlist() error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestClient_RateLimitRetryResendsBody(t *testing.T) {
+	var bodies []string
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"code":"RATE_LIMITED","message":"slow down","retryAfter":0}}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"shareId":"abc"}}`)
+	})
+	WithRateLimitRetry(RateLimitRetry{MaxRetries: 1})(c)
+
+	if _, err := c.AddToWatchlist(context.Background(), "abc"); err != nil {
+		t.Fatalf("AddToWatchlist() error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] != `{"shareId":"abc"}` {
+		t.Errorf("bodies = %q, want the same body twice", bodies)
+	}
+}
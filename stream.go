@@ -0,0 +1,445 @@
+package ramaris
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StreamEventType identifies the kind of a StreamEvent.
+type StreamEventType string
+
+// Stream event types.
+const (
+	// StreamSwap reports a swap made by a followed wallet or a wallet in a
+	// watchlisted strategy.
+	StreamSwap StreamEventType = "swap"
+	// StreamPosition reports a position being opened, changed or closed.
+	StreamPosition StreamEventType = "position"
+	// StreamNotification reports a new notification.
+	StreamNotification StreamEventType = "notification"
+)
+
+// StreamEvent is a live event delivered by Stream. Exactly one of Swap,
+// Position and Notification is set, according to Type.
+type StreamEvent struct {
+	// ID identifies the event within the stream; see Stream.LastEventID.
+	ID   string          `json:"id"`
+	Type StreamEventType `json:"type"`
+	// WalletID is the wallet the event concerns, if any.
+	WalletID int `json:"walletId,omitempty"`
+	// StrategyShareID is set when the event was delivered because of a
+	// watchlisted strategy.
+	StrategyShareID string `json:"strategyShareId,omitempty"`
+
+	Swap         *Swap         `json:"swap,omitempty"`
+	Position     *Position     `json:"position,omitempty"`
+	Notification *Notification `json:"notification,omitempty"`
+}
+
+// StreamFilters narrows the events delivered by Stream. A nil or zero
+// StreamFilters delivers every event for the caller's followed wallets and
+// watchlisted strategies.
+type StreamFilters struct {
+	// Types keeps events of these types.
+	Types []StreamEventType
+	// Wallets and Strategies keep events for these followed wallets and
+	// watchlisted strategy share IDs. When both are set, an event matching
+	// either is kept.
+	Wallets    []int
+	Strategies []string
+}
+
+func (f *StreamFilters) encode(params url.Values) {
+	if f == nil {
+		return
+	}
+	if len(f.Types) > 0 {
+		types := make([]string, len(f.Types))
+		for i, t := range f.Types {
+			types[i] = string(t)
+		}
+		params.Set("types", strings.Join(types, ","))
+	}
+	if len(f.Wallets) > 0 {
+		ids := make([]string, len(f.Wallets))
+		for i, id := range f.Wallets {
+			ids[i] = strconv.Itoa(id)
+		}
+		params.Set("wallets", strings.Join(ids, ","))
+	}
+	if len(f.Strategies) > 0 {
+		params.Set("strategies", strings.Join(f.Strategies, ","))
+	}
+}
+
+const (
+	defaultHeartbeatTimeout = 45 * time.Second
+	defaultStreamBuffer     = 64
+	defaultReconnectDelay   = time.Second
+	maxReconnectDelay       = 30 * time.Second
+	maxStreamLineBytes      = 1 << 20
+)
+
+var (
+	// errHeartbeatTimeout ends a connection that stayed silent for longer
+	// than the heartbeat timeout.
+	errHeartbeatTimeout = errors.New("ramaris: stream heartbeat timeout")
+	// errMalformedEvent ends a stream: reconnecting would only replay the
+	// event that could not be decoded.
+	errMalformedEvent = errors.New("ramaris: failed to decode stream event")
+)
+
+// StreamOption configures Stream.
+type StreamOption func(*streamConfig)
+
+type streamConfig struct {
+	lastEventID      string
+	heartbeatTimeout time.Duration
+	buffer           int
+	dropOldest       bool
+}
+
+// WithLastEventID resumes a stream after the event with the given ID, as
+// reported by an earlier Stream's LastEventID.
+func WithLastEventID(id string) StreamOption {
+	return func(cfg *streamConfig) { cfg.lastEventID = id }
+}
+
+// WithHeartbeatTimeout sets how long a connection may stay silent, with
+// neither events nor heartbeats, before it is considered dead and replaced.
+// Defaults to 45 seconds; the API sends a heartbeat every 15.
+func WithHeartbeatTimeout(d time.Duration) StreamOption {
+	return func(cfg *streamConfig) { cfg.heartbeatTimeout = d }
+}
+
+// WithStreamBuffer sets the capacity of the Events channel. Defaults to 64.
+func WithStreamBuffer(n int) StreamOption {
+	return func(cfg *streamConfig) { cfg.buffer = n }
+}
+
+// WithDropOldest makes a Stream whose Events buffer is full discard the
+// oldest buffered event to make room for a new one, rather than pausing reads
+// until the receiver catches up. Discarded events are counted by Dropped.
+func WithDropOldest() StreamOption {
+	return func(cfg *streamConfig) { cfg.dropOldest = true }
+}
+
+// Stream is a live event stream opened by Client.Stream.
+type Stream struct {
+	c      *Client
+	hc     *http.Client
+	url    string
+	cfg    streamConfig
+	events chan StreamEvent
+	// retry is the base reconnect delay, which the server may change.
+	retry time.Duration
+
+	mu      sync.Mutex
+	lastID  string
+	err     error
+	dropped int
+}
+
+// Stream opens a long-lived connection to the live event endpoint and
+// delivers swap, position and notification events for the caller's followed
+// wallets and watchlisted strategies, narrowed by filters.
+//
+// Stream returns an error if the first connection fails. After that, dropped
+// connections, including ones silent for longer than the heartbeat timeout,
+// are reopened with backoff and resume after the last event received. Events
+// is closed once ctx is done or the API refuses a reconnect with a
+// non-retryable error; Err reports why.
+//
+// A full Events buffer pauses reading from the connection, so a slow
+// receiver pushes back on the server instead of losing events; see
+// WithDropOldest for the alternative.
+func (c *Client) Stream(ctx context.Context, filters *StreamFilters, opts ...StreamOption) (*Stream, error) {
+	cfg := streamConfig{heartbeatTimeout: defaultHeartbeatTimeout, buffer: defaultStreamBuffer}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.dropOldest && cfg.buffer < 1 {
+		cfg.buffer = 1
+	}
+
+	// The connection outlives any request timeout; liveness is checked by
+	// heartbeats instead.
+	hc := *c.httpClient
+	hc.Timeout = 0
+
+	s := &Stream{
+		c:      c,
+		hc:     &hc,
+		url:    c.buildURL("/me/stream", filters),
+		cfg:    cfg,
+		events: make(chan StreamEvent, cfg.buffer),
+		retry:  defaultReconnectDelay,
+		lastID: cfg.lastEventID,
+	}
+	resp, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	go s.run(ctx, resp)
+	return s, nil
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// stream ends.
+func (s *Stream) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// Err returns why the stream ended: ctx's error, or the error that prevented
+// a reconnect. It returns nil while Events is open.
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// LastEventID returns the ID of the last event passed to Events or dropped.
+// Pass it to WithLastEventID to resume in a new Stream.
+func (s *Stream) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastID
+}
+
+// Dropped returns the number of events discarded under WithDropOldest.
+func (s *Stream) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// connect opens a connection through the client's middleware chain, minus
+// retries, resuming after LastEventID if it is set. Each connection attempt
+// is reported to the client's Instrumenter as a call.
+func (s *Stream) connect(ctx context.Context) (resp *http.Response, err error) {
+	call := &apiCall{method: http.MethodGet, url: s.url, attempts: 1}
+	if s.c.instrumenter != nil {
+		var end func(*http.Response, error)
+		ctx, end = s.c.startCall(ctx, call, "/me/stream")
+		defer func() { end(resp, err) }()
+	}
+	req, err := http.NewRequestWithContext(ctx, call.method, call.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ramaris: failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if id := s.LastEventID(); id != "" {
+		req.Header.Set("Last-Event-ID", id)
+	}
+
+	start := time.Now()
+	resp, err = s.c.transport(call, s.hc)(req)
+	s.c.logAttempt(attemptLog{req: req, attempt: 1, resp: resp, err: err, elapsed: time.Since(start)})
+	if err != nil {
+		if err == ctx.Err() {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ramaris: request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, parseError(resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		resp.Body.Close()
+		return nil, fmt.Errorf("ramaris: unexpected stream content type %q", ct)
+	}
+	return resp, nil
+}
+
+// run reads from resp and every later connection until the stream ends.
+func (s *Stream) run(ctx context.Context, resp *http.Response) {
+	defer close(s.events)
+
+	failures := 0
+	for {
+		received, err := s.read(ctx, resp.Body)
+		resp.Body.Close()
+		if received {
+			failures = 0
+		}
+
+		for {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			if !reconnectable(err) {
+				s.mu.Lock()
+				s.err = err
+				s.mu.Unlock()
+				return
+			}
+			delay := s.backoff(failures, err)
+			s.c.logReconnect(ctx, s.url, err, delay)
+			if sleep(ctx, delay) != nil {
+				continue // ends the stream with ctx's error
+			}
+			failures++
+			if resp, err = s.connect(ctx); err == nil {
+				break
+			}
+		}
+	}
+}
+
+// read parses the event stream in body, delivering events until the
+// connection fails or ctx is done. received reports whether any event was
+// delivered.
+func (s *Stream) read(ctx context.Context, body io.ReadCloser) (received bool, err error) {
+	var timedOut atomic.Bool
+	timer := time.AfterFunc(s.cfg.heartbeatTimeout, func() {
+		timedOut.Store(true)
+		body.Close()
+	})
+	defer timer.Stop()
+
+	sc := bufio.NewScanner(body)
+	sc.Buffer(nil, maxStreamLineBytes)
+
+	// Field handling follows the HTML event stream format: the ID persists
+	// until replaced, while type and data are reset after each event.
+	id := s.LastEventID()
+	var (
+		typ  string
+		data []byte
+	)
+	for sc.Scan() {
+		timer.Reset(s.cfg.heartbeatTimeout)
+		line := sc.Text()
+
+		if line == "" {
+			if len(data) > 0 && isStreamEventType(typ) {
+				var ev StreamEvent
+				if err := json.Unmarshal(data[:len(data)-1], &ev); err != nil {
+					return received, fmt.Errorf("%w %q: %w", errMalformedEvent, id, err)
+				}
+				ev.ID, ev.Type = id, StreamEventType(typ)
+
+				timer.Stop()
+				if !s.deliver(ctx, ev) {
+					return received, ctx.Err()
+				}
+				timer.Reset(s.cfg.heartbeatTimeout)
+				received = true
+			}
+			s.mu.Lock()
+			s.lastID = id
+			s.mu.Unlock()
+			typ, data = "", data[:0]
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "":
+			// Comment, sent as a heartbeat.
+		case "event":
+			typ = value
+		case "data":
+			data = append(data, value...)
+			data = append(data, '\n')
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				id = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 32); err == nil {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	if timedOut.Load() {
+		return received, errHeartbeatTimeout
+	}
+	if err := sc.Err(); err != nil {
+		return received, err
+	}
+	return received, io.EOF
+}
+
+// deliver sends ev on Events, reporting false if ctx is done first.
+func (s *Stream) deliver(ctx context.Context, ev StreamEvent) bool {
+	if !s.cfg.dropOldest {
+		select {
+		case s.events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	// Only this goroutine sends, so after one buffered event is taken the
+	// send cannot block.
+	for {
+		select {
+		case s.events <- ev:
+			return true
+		default:
+		}
+		select {
+		case <-s.events:
+			s.mu.Lock()
+			s.dropped++
+			s.mu.Unlock()
+		default:
+		}
+	}
+}
+
+// backoff returns the wait before the next reconnect, which grows from the
+// server's retry delay with each consecutive failure.
+func (s *Stream) backoff(failures int, err error) time.Duration {
+	d := ExponentialBackoff{BaseDelay: s.retry, MaxDelay: max(s.retry, maxReconnectDelay)}.Delay(failures)
+
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) {
+		d = max(d, time.Duration(rlErr.RetryAfter)*time.Second)
+	}
+	return d
+}
+
+// reconnectable reports whether a stream that failed with err should
+// reconnect: after dropped connections, heartbeat timeouts, 5xx responses and
+// rate limiting, but not after other API errors, malformed events or ctx
+// being done.
+func reconnectable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, errMalformedEvent) {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError ||
+			apiErr.StatusCode == http.StatusRequestTimeout
+	}
+	return true
+}
+
+func isStreamEventType(typ string) bool {
+	switch StreamEventType(typ) {
+	case StreamSwap, StreamPosition, StreamNotification:
+		return true
+	}
+	return false
+}
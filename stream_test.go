@@ -0,0 +1,288 @@
+package ramaris
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// writeEvents writes raw event stream text and flushes it to the client.
+func writeEvents(w http.ResponseWriter, text string) {
+	fmt.Fprint(w, text)
+	w.(http.Flusher).Flush()
+}
+
+func startStream(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	writeEvents(w, "retry: 1\n\n")
+}
+
+// nextEvent receives the next event or fails after a timeout.
+func nextEvent(t *testing.T, s *Stream) StreamEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-s.Events():
+		if !ok {
+			t.Fatalf("events closed: %v", s.Err())
+		}
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return StreamEvent{}
+}
+
+// waitClosed waits for the stream to end and returns its error.
+func waitClosed(t *testing.T, s *Stream) error {
+	t.Helper()
+	for {
+		select {
+		case _, ok := <-s.Events():
+			if !ok {
+				return s.Err()
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for the stream to end")
+		}
+	}
+}
+
+func TestStream_TypedEvents(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.String(); got != "/me/stream?strategies=abc&types=swap%2Cnotification&wallets=456%2C789" {
+			t.Errorf("URL = %s", got)
+		}
+		if got := r.Header.Get("Accept"); got != "text/event-stream" {
+			t.Errorf("Accept = %q, want text/event-stream", got)
+		}
+		startStream(w)
+		writeEvents(w, ": ping\n\n"+
+			"event: ping\ndata: {}\n\n"+
+			"id: 1\nevent: swap\ndata: {\"walletId\":456,\ndata: \"swap\":{\"txHash\":\"0xabc\",\"amountIn\":\"0.5\"}}\n\n"+
+			"id: 2\nevent: position\r\ndata: {\"walletId\":456,\"position\":{\"symbol\":\"WETH\"}}\r\n\r\n"+
+			"id: 3\nevent: notification\ndata:{\"strategyShareId\":\"abc\",\"notification\":{\"id\":\"n1\",\"type\":\"SWAP_DETECTED\"}}\n\n")
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := c.Stream(ctx, &StreamFilters{
+		Types:      []StreamEventType{StreamSwap, StreamNotification},
+		Wallets:    []int{456, 789},
+		Strategies: []string{"abc"},
+	})
+	if err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+
+	ev := nextEvent(t, s)
+	if ev.ID != "1" || ev.Type != StreamSwap || ev.WalletID != 456 || ev.Swap == nil || ev.Swap.TxHash != "0xabc" {
+		t.Errorf("first event = %+v, want swap 0xabc for wallet 456", ev)
+	}
+	ev = nextEvent(t, s)
+	if ev.ID != "2" || ev.Type != StreamPosition || ev.Position == nil || ev.Position.Symbol != "WETH" {
+		t.Errorf("second event = %+v, want WETH position", ev)
+	}
+	ev = nextEvent(t, s)
+	if ev.Type != StreamNotification || ev.StrategyShareID != "abc" || ev.Notification == nil || ev.Notification.ID != "n1" {
+		t.Errorf("third event = %+v, want notification n1", ev)
+	}
+	if got := s.LastEventID(); got != "3" {
+		t.Errorf("LastEventID() = %q, want 3", got)
+	}
+
+	cancel()
+	if err := waitClosed(t, s); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", err)
+	}
+}
+
+func TestStream_ReconnectResumes(t *testing.T) {
+	var conns atomic.Int32
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := conns.Add(1)
+		switch n {
+		case 1:
+			if got := r.Header.Get("Last-Event-ID"); got != "7" {
+				t.Errorf("first Last-Event-ID = %q, want 7 from WithLastEventID", got)
+			}
+			startStream(w)
+			writeEvents(w, "id: 8\nevent: swap\ndata: {\"walletId\":1}\n\n")
+			// Closing the connection drops the stream.
+		case 2:
+			// A failed reconnect is retried.
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			if got := r.Header.Get("Last-Event-ID"); got != "8" {
+				t.Errorf("reconnect Last-Event-ID = %q, want 8", got)
+			}
+			startStream(w)
+			writeEvents(w, "id: 9\nevent: swap\ndata: {\"walletId\":1}\n\n")
+			<-r.Context().Done()
+		}
+	})
+
+	var logs bytes.Buffer
+	WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))(c)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := c.Stream(ctx, nil, WithLastEventID("7"))
+	if err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+	if ev := nextEvent(t, s); ev.ID != "8" {
+		t.Errorf("first event ID = %q, want 8", ev.ID)
+	}
+	if ev := nextEvent(t, s); ev.ID != "9" {
+		t.Errorf("event after reconnect ID = %q, want 9", ev.ID)
+	}
+	if got := conns.Load(); got != 3 {
+		t.Errorf("connections = %d, want 3", got)
+	}
+	if n := strings.Count(logs.String(), `msg="ramaris: reconnecting stream"`); n != 2 {
+		t.Errorf("logged %d reconnects, want 2:\n%s", n, logs.String())
+	}
+}
+
+func TestStream_HeartbeatTimeout(t *testing.T) {
+	var conns atomic.Int32
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := conns.Add(1)
+		startStream(w)
+		writeEvents(w, fmt.Sprintf("id: %d\nevent: swap\ndata: {}\n\n", n))
+		if n == 1 {
+			// Heartbeats keep the first connection alive for a while, then
+			// it goes silent without closing.
+			for range 3 {
+				time.Sleep(30 * time.Millisecond)
+				writeEvents(w, ":\n")
+			}
+		}
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := c.Stream(ctx, nil, WithHeartbeatTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+	nextEvent(t, s)
+	start := time.Now()
+	if ev := nextEvent(t, s); ev.ID != "2" {
+		t.Errorf("event after timeout ID = %q, want 2", ev.ID)
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("reconnected after %v, want heartbeats to keep the first connection open", elapsed)
+	}
+}
+
+func TestStream_Backpressure(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		startStream(w)
+		for i := 1; i <= 3; i++ {
+			writeEvents(w, fmt.Sprintf("id: %d\nevent: swap\ndata: {}\n\n", i))
+		}
+		<-r.Context().Done()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("block", func(t *testing.T) {
+		s, err := c.Stream(ctx, nil, WithStreamBuffer(1), WithHeartbeatTimeout(20*time.Millisecond))
+		if err != nil {
+			t.Fatalf("Stream() error: %v", err)
+		}
+		// A receiver slower than the heartbeat timeout loses nothing.
+		time.Sleep(50 * time.Millisecond)
+		for i := 1; i <= 3; i++ {
+			if ev := nextEvent(t, s); ev.ID != fmt.Sprint(i) {
+				t.Errorf("event ID = %q, want %d", ev.ID, i)
+			}
+		}
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		s, err := c.Stream(ctx, nil, WithStreamBuffer(1), WithDropOldest())
+		if err != nil {
+			t.Fatalf("Stream() error: %v", err)
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for s.LastEventID() != "3" && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if ev := nextEvent(t, s); ev.ID != "3" {
+			t.Errorf("event ID = %q, want latest (3)", ev.ID)
+		}
+		if got := s.Dropped(); got != 2 {
+			t.Errorf("Dropped() = %d, want 2", got)
+		}
+	})
+}
+
+func TestStream_Errors(t *testing.T) {
+	t.Run("first connection", func(t *testing.T) {
+		_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"code":"UNAUTHORIZED","message":"bad key"}}`)
+		})
+		_, err := c.Stream(context.Background(), nil)
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Code != "UNAUTHORIZED" {
+			t.Errorf("Stream() error = %v, want UNAUTHORIZED *Error", err)
+		}
+	})
+
+	t.Run("not an event stream", func(t *testing.T) {
+		_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{}`)
+		})
+		if _, err := c.Stream(context.Background(), nil); err == nil {
+			t.Error("Stream() error = nil, want content type error")
+		}
+	})
+
+	t.Run("reconnect refused", func(t *testing.T) {
+		var conns atomic.Int32
+		_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if conns.Add(1) > 1 {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"error":{"code":"FORBIDDEN","message":"subscription expired"}}`)
+				return
+			}
+			startStream(w)
+		})
+		s, err := c.Stream(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Stream() error: %v", err)
+		}
+		var apiErr *Error
+		if err := waitClosed(t, s); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+			t.Errorf("Err() = %v, want 403 *Error", err)
+		}
+	})
+
+	t.Run("malformed event", func(t *testing.T) {
+		_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			startStream(w)
+			writeEvents(w, "id: 1\nevent: swap\ndata: {\n\n")
+			<-r.Context().Done()
+		})
+		s, err := c.Stream(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Stream() error: %v", err)
+		}
+		if err := waitClosed(t, s); !errors.Is(err, errMalformedEvent) {
+			t.Errorf("Err() = %v, want malformed event error", err)
+		}
+	})
+}
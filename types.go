@@ -22,6 +22,19 @@ type ListResponse[T any] struct {
 	Pagination Pagination `json:"pagination"`
 }
 
+// CursorPagination describes the position of a cursor-paginated list response.
+type CursorPagination struct {
+	// NextCursor fetches the following page; empty when HasMore is false.
+	NextCursor string `json:"nextCursor"`
+	HasMore    bool   `json:"hasMore"`
+}
+
+// CursorResponse is a cursor-paginated list of items.
+type CursorResponse[T any] struct {
+	Data       []T              `json:"data"`
+	Pagination CursorPagination `json:"pagination"`
+}
+
 // RateLimitInfo holds rate limit state from response headers.
 type RateLimitInfo struct {
 	Limit     int `json:"limit"`
@@ -87,6 +100,11 @@ type WatchlistStrategy struct {
 	CopiedAt       time.Time       `json:"copiedAt"`
 }
 
+// watchlistRequest is the body of an add-to-watchlist request.
+type watchlistRequest struct {
+	ShareID string `json:"shareId"`
+}
+
 // WalletStats holds aggregate stats for a wallet list item.
 type WalletStats struct {
 	TotalSwaps    int `json:"totalSwaps"`
@@ -103,6 +121,20 @@ type WalletListItem struct {
 	Tags        []string    `json:"tags"`
 }
 
+// StrategyWallet is a wallet tracked by a strategy, with its membership details.
+type StrategyWallet struct {
+	WalletListItem
+	// Weight is the wallet's share of the strategy, from 0 to 1.
+	Weight  float64   `json:"weight"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// FollowedWallet is a wallet the authenticated user follows.
+type FollowedWallet struct {
+	WalletListItem
+	FollowedAt time.Time `json:"followedAt"`
+}
+
 // WalletDetailStats extends WalletStats with follower count.
 type WalletDetailStats struct {
 	TotalSwaps    int `json:"totalSwaps"`
@@ -129,6 +161,78 @@ type Wallet struct {
 	TopTokens   []TopToken        `json:"topTokens"`
 }
 
+// SwapToken identifies a token on one side of a swap.
+type SwapToken struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+// Swap is a single on-chain swap made by a wallet.
+type Swap struct {
+	TxHash      string    `json:"txHash"`
+	LogIndex    int       `json:"logIndex"`
+	BlockNumber uint64    `json:"blockNumber"`
+	Timestamp   time.Time `json:"timestamp"`
+	TokenIn     SwapToken `json:"tokenIn"`
+	TokenOut    SwapToken `json:"tokenOut"`
+	AmountIn    Decimal   `json:"amountIn"`
+	AmountOut   Decimal   `json:"amountOut"`
+	ValueUsd    *Decimal  `json:"valueUsd"`
+	RealizedPnL *Decimal  `json:"realizedPnL"`
+}
+
+// Position is a wallet's open position in a single token. Amounts are exact
+// decimals; UnrealizedPnL is empty when the token has no current price.
+type Position struct {
+	TokenAddress      string    `json:"tokenAddress"`
+	Symbol            string    `json:"symbol"`
+	Size              Decimal   `json:"size"`
+	AverageEntryPrice Decimal   `json:"averageEntryPrice"`
+	CostBasisUsd      Decimal   `json:"costBasisUsd"`
+	UnrealizedPnL     Decimal   `json:"unrealizedPnL"`
+	OpenedAt          time.Time `json:"openedAt"`
+}
+
+// NotificationType identifies the event a notification reports.
+type NotificationType string
+
+// Notification types.
+const (
+	NotificationSwapDetected     NotificationType = "SWAP_DETECTED"
+	NotificationPositionOpened   NotificationType = "POSITION_OPENED"
+	NotificationPositionClosed   NotificationType = "POSITION_CLOSED"
+	NotificationThresholdCrossed NotificationType = "THRESHOLD_CROSSED"
+)
+
+// ThresholdCrossing describes a strategy metric crossing an alert threshold.
+type ThresholdCrossing struct {
+	Metric    string  `json:"metric"`
+	Threshold float64 `json:"threshold"`
+	Value     float64 `json:"value"`
+	// Direction is "above" or "below".
+	Direction string `json:"direction"`
+}
+
+// Notification is an alert raised for a strategy. Exactly one of Swap,
+// Position or Threshold is set, depending on Type.
+type Notification struct {
+	ID              string           `json:"id"`
+	Type            NotificationType `json:"type"`
+	StrategyShareID string           `json:"strategyShareId"`
+	WalletID        *int             `json:"walletId"`
+	Message         string           `json:"message"`
+	Read            bool             `json:"read"`
+	CreatedAt       time.Time        `json:"createdAt"`
+
+	// Swap is set for SWAP_DETECTED.
+	Swap *Swap `json:"swap,omitempty"`
+	// Position is set for POSITION_OPENED and POSITION_CLOSED.
+	Position *Position `json:"position,omitempty"`
+	// Threshold is set for THRESHOLD_CROSSED.
+	Threshold *ThresholdCrossing `json:"threshold,omitempty"`
+}
+
 // UserProfileStats holds aggregate user stats.
 type UserProfileStats struct {
 	StrategiesCreated  int `json:"strategiesCreated"`
@@ -180,8 +284,9 @@ type singleResponse[T any] struct {
 // errorResponse is the API error envelope.
 type errorResponse struct {
 	Error struct {
-		Code       string `json:"code"`
-		Message    string `json:"message"`
-		RetryAfter int    `json:"retryAfter,omitempty"`
+		Code       string       `json:"code"`
+		Message    string       `json:"message"`
+		RetryAfter int          `json:"retryAfter,omitempty"`
+		Details    []FieldError `json:"details,omitempty"`
 	} `json:"error"`
 }
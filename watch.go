@@ -0,0 +1,311 @@
+package ramaris
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// ChangeKind identifies what changed in a watched strategy or wallet.
+type ChangeKind string
+
+// Change kinds.
+const (
+	// ChangeROI reports a strategy's ROIPercent moving by at least
+	// WatchConfig.MinROIChange percentage points.
+	ChangeROI ChangeKind = "ROI"
+	// ChangeStatus reports a strategy or wallet status change.
+	ChangeStatus ChangeKind = "STATUS"
+	// ChangeTags reports tags added to or removed from a strategy or wallet.
+	ChangeTags ChangeKind = "TAGS"
+	// ChangeNewSwaps reports swaps made by a wallet since the last poll.
+	ChangeNewSwaps ChangeKind = "NEW_SWAPS"
+	// ChangeError reports a failed fetch. Watching continues with the next
+	// poll.
+	ChangeError ChangeKind = "ERROR"
+)
+
+// Change is an event emitted by Watch. ShareID is set for strategy changes
+// and WalletID for wallet changes; the remaining fields depend on Kind.
+type Change struct {
+	Kind       ChangeKind `json:"kind"`
+	ShareID    string     `json:"shareId,omitempty"`
+	WalletID   int        `json:"walletId,omitempty"`
+	DetectedAt time.Time  `json:"detectedAt"`
+
+	// OldROI and NewROI are set for ChangeROI.
+	OldROI *float64 `json:"oldRoi,omitempty"`
+	NewROI *float64 `json:"newRoi,omitempty"`
+	// OldStatus and NewStatus are set for ChangeStatus.
+	OldStatus string `json:"oldStatus,omitempty"`
+	NewStatus string `json:"newStatus,omitempty"`
+	// AddedTags and RemovedTags are set for ChangeTags.
+	AddedTags   []string `json:"addedTags,omitempty"`
+	RemovedTags []string `json:"removedTags,omitempty"`
+	// Swaps holds the new swaps for ChangeNewSwaps, most recent first.
+	Swaps []Swap `json:"swaps,omitempty"`
+	// Err is set for ChangeError.
+	Err error `json:"-"`
+}
+
+// WatchConfig configures Watch.
+type WatchConfig struct {
+	// Strategies and Wallets are the share IDs and wallet IDs to watch.
+	Strategies []string
+	Wallets    []int
+	// Interval is the time between polls. Defaults to one minute.
+	Interval time.Duration
+	// MinROIChange is the smallest ROI move, in percentage points, that
+	// produces a ChangeROI. Zero reports every change.
+	MinROIChange float64
+	// Reserve is the number of requests in the rate limit window left for
+	// other callers: once RateLimit().Remaining drops to Reserve, the watcher
+	// waits for the window to reset before its next request.
+	Reserve int
+}
+
+// Watch polls the configured strategies and wallets every cfg.Interval and
+// sends a Change on the returned channel for each difference between
+// successive snapshots. The first poll only records a baseline. The channel
+// is closed once ctx is done; changes are not dropped, so polling pauses
+// while the receiver falls behind.
+func (c *Client) Watch(ctx context.Context, cfg WatchConfig) <-chan Change {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	w := &watcher{
+		c:          c,
+		cfg:        cfg,
+		changes:    make(chan Change),
+		strategies: make(map[string]*strategySnapshot),
+		wallets:    make(map[int]*walletSnapshot),
+	}
+	go w.run(ctx)
+	return w.changes
+}
+
+type watcher struct {
+	c          *Client
+	cfg        WatchConfig
+	changes    chan Change
+	strategies map[string]*strategySnapshot
+	wallets    map[int]*walletSnapshot
+}
+
+type strategySnapshot struct {
+	roi    *float64
+	status string
+	tags   []string
+}
+
+type walletSnapshot struct {
+	status     string
+	tags       []string
+	totalSwaps int
+	// latest is the most recent swap seen, or nil if the wallet had none.
+	latest *Swap
+}
+
+func (w *watcher) run(ctx context.Context) {
+	defer close(w.changes)
+	for {
+		for _, id := range w.cfg.Strategies {
+			if !w.pollStrategy(ctx, id) {
+				return
+			}
+		}
+		for _, id := range w.cfg.Wallets {
+			if !w.pollWallet(ctx, id) {
+				return
+			}
+		}
+		if sleep(ctx, w.cfg.Interval) != nil {
+			return
+		}
+	}
+}
+
+// emit sends ch, reporting false if ctx is done first.
+func (w *watcher) emit(ctx context.Context, ch Change) bool {
+	ch.DetectedAt = time.Now()
+	select {
+	case w.changes <- ch:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// budget waits for the rate limit window to reset if the remaining budget is
+// down to the reserve.
+func (w *watcher) budget(ctx context.Context) error {
+	rl := w.c.RateLimit()
+	if rl == nil || rl.Remaining > w.cfg.Reserve {
+		return nil
+	}
+	if d := time.Until(time.Unix(int64(rl.Reset), 0)); d > 0 {
+		return sleep(ctx, d)
+	}
+	return nil
+}
+
+// pollStrategy fetches a strategy and emits its changes. It reports false
+// once ctx is done.
+func (w *watcher) pollStrategy(ctx context.Context, shareID string) bool {
+	if w.budget(ctx) != nil {
+		return false
+	}
+	s, err := w.c.GetStrategy(ctx, shareID)
+	if err != nil {
+		return ctx.Err() == nil && w.emit(ctx, Change{Kind: ChangeError, ShareID: shareID, Err: err})
+	}
+
+	prev := w.strategies[shareID]
+	if prev == nil {
+		w.strategies[shareID] = &strategySnapshot{roi: s.ROIPercent, status: s.Status, tags: s.Tags}
+		return true
+	}
+
+	if roiChanged(prev.roi, s.ROIPercent, w.cfg.MinROIChange) {
+		if !w.emit(ctx, Change{Kind: ChangeROI, ShareID: shareID, OldROI: prev.roi, NewROI: s.ROIPercent}) {
+			return false
+		}
+		prev.roi = s.ROIPercent
+	}
+	if s.Status != prev.status {
+		if !w.emit(ctx, Change{Kind: ChangeStatus, ShareID: shareID, OldStatus: prev.status, NewStatus: s.Status}) {
+			return false
+		}
+		prev.status = s.Status
+	}
+	if added, removed := diffTags(prev.tags, s.Tags); added != nil || removed != nil {
+		if !w.emit(ctx, Change{Kind: ChangeTags, ShareID: shareID, AddedTags: added, RemovedTags: removed}) {
+			return false
+		}
+		prev.tags = s.Tags
+	}
+	return true
+}
+
+// pollWallet fetches a wallet, and its new swaps if its swap count grew, and
+// emits its changes. It reports false once ctx is done.
+func (w *watcher) pollWallet(ctx context.Context, id int) bool {
+	if w.budget(ctx) != nil {
+		return false
+	}
+	wl, err := w.c.GetWallet(ctx, id)
+	if err != nil {
+		return ctx.Err() == nil && w.emit(ctx, Change{Kind: ChangeError, WalletID: id, Err: err})
+	}
+
+	prev := w.wallets[id]
+	if prev == nil {
+		snap := &walletSnapshot{status: wl.Status, tags: wl.Tags, totalSwaps: wl.Stats.TotalSwaps}
+		if wl.Stats.TotalSwaps > 0 {
+			swaps, err := w.newSwaps(ctx, id, nil, 1)
+			if err != nil {
+				return ctx.Err() == nil && w.emit(ctx, Change{Kind: ChangeError, WalletID: id, Err: err})
+			}
+			if len(swaps) > 0 {
+				snap.latest = &swaps[0]
+			}
+		}
+		w.wallets[id] = snap
+		return true
+	}
+
+	if wl.Status != prev.status {
+		if !w.emit(ctx, Change{Kind: ChangeStatus, WalletID: id, OldStatus: prev.status, NewStatus: wl.Status}) {
+			return false
+		}
+		prev.status = wl.Status
+	}
+	if added, removed := diffTags(prev.tags, wl.Tags); added != nil || removed != nil {
+		if !w.emit(ctx, Change{Kind: ChangeTags, WalletID: id, AddedTags: added, RemovedTags: removed}) {
+			return false
+		}
+		prev.tags = wl.Tags
+	}
+	// The count only tells whether to look: more swaps may land between the
+	// two requests, so newSwaps pages back to the last one seen instead.
+	if n := wl.Stats.TotalSwaps - prev.totalSwaps; n > 0 {
+		swaps, err := w.newSwaps(ctx, id, prev.latest, n)
+		if err != nil {
+			return ctx.Err() == nil && w.emit(ctx, Change{Kind: ChangeError, WalletID: id, Err: err})
+		}
+		if len(swaps) > 0 {
+			if !w.emit(ctx, Change{Kind: ChangeNewSwaps, WalletID: id, Swaps: swaps}) {
+				return false
+			}
+			prev.latest = &swaps[0]
+		}
+	}
+	prev.totalSwaps = wl.Stats.TotalSwaps
+	return true
+}
+
+// newSwaps returns a wallet's swaps more recent than after, most recent
+// first. n is the expected number of new swaps and sizes the pages; it bounds
+// the result only when after is nil, so that any swaps are returned.
+func (w *watcher) newSwaps(ctx context.Context, walletID int, after *Swap, n int) ([]Swap, error) {
+	var swaps []Swap
+	opts := &SwapListOptions{Limit: min(n, 100)}
+	for {
+		if err := w.budget(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := w.c.ListWalletSwaps(ctx, walletID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, sw := range resp.Data {
+			if after != nil && !swapAfter(sw, *after) {
+				return swaps, nil
+			}
+			swaps = append(swaps, sw)
+			if after == nil && len(swaps) == n {
+				return swaps, nil
+			}
+		}
+		if !resp.Pagination.HasMore {
+			return swaps, nil
+		}
+		if resp.Pagination.NextCursor == "" {
+			return nil, fmt.Errorf("ramaris: wallet %d swaps have more pages but no cursor", walletID)
+		}
+		opts.Cursor = resp.Pagination.NextCursor
+	}
+}
+
+// swapAfter reports whether a happened after b on chain.
+func swapAfter(a, b Swap) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber > b.BlockNumber
+	}
+	return a.LogIndex > b.LogIndex
+}
+
+func roiChanged(old, cur *float64, threshold float64) bool {
+	if old == nil || cur == nil {
+		return (old == nil) != (cur == nil)
+	}
+	d := math.Abs(*cur - *old)
+	return d > 0 && d >= threshold
+}
+
+// diffTags returns the tags in cur but not old, and in old but not cur.
+func diffTags(old, cur []string) (added, removed []string) {
+	for _, t := range cur {
+		if !slices.Contains(old, t) {
+			added = append(added, t)
+		}
+	}
+	for _, t := range old {
+		if !slices.Contains(cur, t) {
+			removed = append(removed, t)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,231 @@
+package ramaris
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// watchAPI serves mutable strategy and wallet state for watcher tests.
+type watchAPI struct {
+	mu       sync.Mutex
+	strategy Strategy
+	wallet   Wallet
+	swaps    []Swap // most recent first
+	noCursor bool   // report more swap pages without a cursor
+	requests int
+}
+
+func (a *watchAPI) set(f func(a *watchAPI)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f(a)
+}
+
+func (a *watchAPI) handle(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.requests++
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/strategies/abc":
+		json.NewEncoder(w).Encode(singleResponse[Strategy]{Data: a.strategy})
+	case "/wallets/456":
+		json.NewEncoder(w).Encode(singleResponse[Wallet]{Data: a.wallet})
+	case "/wallets/456/swaps":
+		off, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := min(off+limit, len(a.swaps))
+		resp := CursorResponse[Swap]{Data: a.swaps[off:end]}
+		resp.Pagination.HasMore = end < len(a.swaps)
+		if resp.Pagination.HasMore && !a.noCursor {
+			resp.Pagination.NextCursor = strconv.Itoa(end)
+		}
+		json.NewEncoder(w).Encode(resp)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":"NOT_FOUND","message":"not found"}}`)
+	}
+}
+
+// waitRequests waits until the API has served at least n requests.
+func (a *watchAPI) waitRequests(n int) {
+	for {
+		a.mu.Lock()
+		served := a.requests
+		a.mu.Unlock()
+		if served >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// next receives the next change or fails after a timeout.
+func next(t *testing.T, changes <-chan Change) Change {
+	t.Helper()
+	select {
+	case ch, ok := <-changes:
+		if !ok {
+			t.Fatal("changes closed")
+		}
+		return ch
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a change")
+	}
+	return Change{}
+}
+
+func TestWatch_Changes(t *testing.T) {
+	api := &watchAPI{
+		strategy: Strategy{ShareID: "abc", ROIPercent: Float64(10), Status: "ACTIVE", Tags: []string{"base"}},
+		wallet:   Wallet{ID: 456, Status: "ACTIVE", Stats: WalletDetailStats{TotalSwaps: 1}},
+		swaps:    []Swap{{TxHash: "0x1", BlockNumber: 1}},
+	}
+	_, c := newTestServer(t, api.handle)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := c.Watch(ctx, WatchConfig{
+		Strategies:   []string{"abc"},
+		Wallets:      []int{456},
+		Interval:     10 * time.Millisecond,
+		MinROIChange: 1,
+	})
+
+	// Wait for the baseline poll before changing anything.
+	api.waitRequests(3)
+	api.set(func(a *watchAPI) {
+		a.strategy.ROIPercent = Float64(10.5) // below MinROIChange
+		a.strategy.Status = "PAUSED"
+		a.strategy.Tags = []string{"defi"}
+		a.wallet.Stats.TotalSwaps = 3
+		a.swaps = append([]Swap{{TxHash: "0x3", BlockNumber: 3}, {TxHash: "0x2", BlockNumber: 2}}, a.swaps...)
+	})
+
+	ch := next(t, changes)
+	if ch.Kind != ChangeStatus || ch.ShareID != "abc" || ch.OldStatus != "ACTIVE" || ch.NewStatus != "PAUSED" {
+		t.Errorf("change = %+v, want strategy status change", ch)
+	}
+	ch = next(t, changes)
+	if ch.Kind != ChangeTags || fmt.Sprint(ch.AddedTags, ch.RemovedTags) != "[defi] [base]" {
+		t.Errorf("change = %+v, want tag change", ch)
+	}
+	ch = next(t, changes)
+	if ch.Kind != ChangeNewSwaps || ch.WalletID != 456 || len(ch.Swaps) != 2 || ch.Swaps[0].TxHash != "0x3" {
+		t.Errorf("change = %+v, want two new swaps", ch)
+	}
+
+	// The small move and a second one add up past the threshold.
+	api.set(func(a *watchAPI) { a.strategy.ROIPercent = Float64(11.5) })
+	ch = next(t, changes)
+	if ch.Kind != ChangeROI || *ch.OldROI != 10 || *ch.NewROI != 11.5 {
+		t.Errorf("change = %+v, want ROI 10 -> 11.5", ch)
+	}
+
+	cancel()
+	for range changes {
+	}
+}
+
+func TestWatch_SwapsLandingMidPoll(t *testing.T) {
+	api := &watchAPI{
+		wallet: Wallet{ID: 456, Stats: WalletDetailStats{TotalSwaps: 1}},
+		swaps:  []Swap{{TxHash: "0x1", BlockNumber: 1}},
+	}
+	_, c := newTestServer(t, api.handle)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := c.Watch(ctx, WatchConfig{Wallets: []int{456}, Interval: 10 * time.Millisecond})
+	api.waitRequests(2)
+	// The wallet reports one new swap, but 0x3 lands before the swaps are
+	// listed, so 0x2 is on the second page.
+	api.set(func(a *watchAPI) {
+		a.wallet.Stats.TotalSwaps = 2
+		a.swaps = append([]Swap{{TxHash: "0x3", BlockNumber: 3}, {TxHash: "0x2", BlockNumber: 2}}, a.swaps...)
+	})
+
+	ch := next(t, changes)
+	if ch.Kind != ChangeNewSwaps || len(ch.Swaps) != 2 || ch.Swaps[0].TxHash != "0x3" || ch.Swaps[1].TxHash != "0x2" {
+		t.Errorf("change = %+v, want swaps 0x3 and 0x2", ch)
+	}
+
+	// Catching up with the count does not report 0x3 again.
+	api.set(func(a *watchAPI) {
+		a.wallet.Stats.TotalSwaps = 4
+		a.swaps = append([]Swap{{TxHash: "0x4", BlockNumber: 4}}, a.swaps...)
+	})
+	ch = next(t, changes)
+	if ch.Kind != ChangeNewSwaps || len(ch.Swaps) != 1 || ch.Swaps[0].TxHash != "0x4" {
+		t.Errorf("change = %+v, want swap 0x4", ch)
+	}
+}
+
+func TestWatch_SwapPagesWithoutCursor(t *testing.T) {
+	api := &watchAPI{
+		wallet: Wallet{ID: 456, Stats: WalletDetailStats{TotalSwaps: 1}},
+		swaps:  []Swap{{TxHash: "0x1", BlockNumber: 1}},
+	}
+	_, c := newTestServer(t, api.handle)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := c.Watch(ctx, WatchConfig{Wallets: []int{456}, Interval: 10 * time.Millisecond})
+	api.waitRequests(2)
+	api.set(func(a *watchAPI) {
+		a.noCursor = true
+		a.wallet.Stats.TotalSwaps = 2
+		a.swaps = append([]Swap{{TxHash: "0x3", BlockNumber: 3}, {TxHash: "0x2", BlockNumber: 2}}, a.swaps...)
+	})
+
+	if ch := next(t, changes); ch.Kind != ChangeError || ch.WalletID != 456 || ch.Err == nil {
+		t.Errorf("change = %+v, want an error for the missing cursor", ch)
+	}
+}
+
+func TestWatch_Error(t *testing.T) {
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":"NOT_FOUND","message":"strategy not found"}}`)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := next(t, c.Watch(ctx, WatchConfig{Strategies: []string{"gone"}, Interval: time.Hour}))
+	var apiErr *Error
+	if ch.Kind != ChangeError || ch.ShareID != "gone" || !errors.As(ch.Err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("change = %+v, want 404 error", ch)
+	}
+}
+
+func TestWatch_RespectsRateLimitReserve(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	_, c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "5")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		fmt.Fprint(w, `{"data":{"shareId":"abc","status":"ACTIVE"}}`)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	for range c.Watch(ctx, WatchConfig{Strategies: []string{"abc"}, Interval: time.Millisecond, Reserve: 5}) {
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 before the window resets", requests)
+	}
+}
@@ -0,0 +1,301 @@
+// Package webhook receives Ramaris webhook deliveries.
+//
+// A Handler verifies each delivery's HMAC-SHA256 signature and timestamp,
+// decodes the event and calls the callback registered for its type:
+//
+//	h, err := webhook.NewHandler(os.Getenv("RAMARIS_WEBHOOK_SECRET"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	h.OnStrategyNotification(func(ctx context.Context, e *webhook.StrategyNotificationEvent) error {
+//		log.Printf("%s: %s", e.Notification.StrategyShareID, e.Notification.Message)
+//		return nil
+//	})
+//	http.Handle("/webhooks/ramaris", h)
+//
+// Deliveries are retried by Ramaris until the handler responds with a 2xx,
+// so the same event may arrive more than once; use Event.ID to deduplicate.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ramaris "github.com/ramaris-app/go-sdk"
+)
+
+// SignatureHeader is the request header carrying a delivery's signature, in
+// the form "t=<unix seconds>,v1=<hex HMAC-SHA256>". The HMAC covers the
+// timestamp, a period and the raw request body. Several v1 entries may be
+// present while a secret is being rotated.
+const SignatureHeader = "Ramaris-Signature"
+
+// DefaultTolerance is the default maximum age of a delivery's timestamp.
+const DefaultTolerance = 5 * time.Minute
+
+// maxBodyBytes bounds the size of a delivery.
+const maxBodyBytes = 1 << 20
+
+var (
+	// ErrInvalidSignature is returned by Verify when the signature header is
+	// missing, malformed, or does not match the body, and when the secret is
+	// empty.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	// ErrTimestampOutOfRange is returned by Verify when the signed timestamp
+	// is outside the tolerance, which indicates a replayed delivery.
+	ErrTimestampOutOfRange = errors.New("webhook: timestamp outside tolerance")
+	// ErrEmptySecret is returned by NewHandler when the secret is empty.
+	ErrEmptySecret = errors.New("webhook: empty secret")
+)
+
+// EventType identifies the kind of event in a delivery.
+type EventType string
+
+// Event types.
+const (
+	// EventStrategyNotification is sent when a strategy on the user's
+	// watchlist, or one they created, raises a notification.
+	EventStrategyNotification EventType = "strategy.notification"
+	// EventWalletNotification is sent when a followed wallet swaps or opens
+	// or closes a position.
+	EventWalletNotification EventType = "wallet.notification"
+)
+
+// Event is a delivery's envelope. Data holds the type-specific payload.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// StrategyNotificationEvent is the payload of an EventStrategyNotification.
+type StrategyNotificationEvent struct {
+	ID           string
+	CreatedAt    time.Time
+	Notification ramaris.Notification
+}
+
+// WalletNotificationEvent is the payload of an EventWalletNotification.
+// Notification.WalletID is always set and Notification.StrategyShareID is
+// empty.
+type WalletNotificationEvent struct {
+	ID           string
+	CreatedAt    time.Time
+	Notification ramaris.Notification
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithTolerance sets the maximum age of a delivery's timestamp, and how far
+// it may be in the future. Deliveries outside it are rejected as replays.
+func WithTolerance(d time.Duration) Option {
+	return func(h *Handler) { h.tolerance = d }
+}
+
+// Handler is an http.Handler that verifies and dispatches webhook deliveries.
+// Register callbacks before serving requests.
+//
+// It responds 401 to deliveries with a bad signature or stale timestamp,
+// 400 to malformed events, 500 when a callback returns an error (so the
+// delivery is retried) and 200 otherwise, including for event types without
+// a callback.
+type Handler struct {
+	secret    string
+	tolerance time.Duration
+
+	mu       sync.RWMutex
+	strategy func(context.Context, *StrategyNotificationEvent) error
+	wallet   func(context.Context, *WalletNotificationEvent) error
+	fallback func(context.Context, *Event) error
+}
+
+// NewHandler returns a Handler that accepts deliveries signed with secret.
+// It returns ErrEmptySecret if secret is empty, since anyone can sign with an
+// empty key; this catches an unset secret, such as a missing environment
+// variable, at startup.
+func NewHandler(secret string, opts ...Option) (*Handler, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	h := &Handler{
+		secret:    secret,
+		tolerance: DefaultTolerance,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+// OnStrategyNotification registers the callback for strategy notifications.
+func (h *Handler) OnStrategyNotification(fn func(context.Context, *StrategyNotificationEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.strategy = fn
+}
+
+// OnWalletNotification registers the callback for followed wallet
+// notifications.
+func (h *Handler) OnWalletNotification(fn func(context.Context, *WalletNotificationEvent) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.wallet = fn
+}
+
+// OnEvent registers a callback for event types without a typed callback,
+// such as types added after this package was released.
+func (h *Handler) OnEvent(fn func(context.Context, *Event) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = fn
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := Verify(h.secret, r.Header.Get(SignatureHeader), body, time.Now(), h.tolerance); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var e Event
+	if err := json.Unmarshal(body, &e); err != nil || e.ID == "" || e.Type == "" {
+		http.Error(w, "malformed event", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), &e); err != nil {
+		var decodeErr *decodeError
+		if errors.As(err, &decodeErr) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "event handler failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// decodeError reports an event payload that does not match its type.
+type decodeError struct {
+	typ EventType
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("webhook: malformed %s payload: %v", e.typ, e.err)
+}
+
+func (h *Handler) dispatch(ctx context.Context, e *Event) error {
+	h.mu.RLock()
+	strategy, wallet, fallback := h.strategy, h.wallet, h.fallback
+	h.mu.RUnlock()
+
+	switch {
+	case e.Type == EventStrategyNotification && strategy != nil:
+		var n ramaris.Notification
+		if err := json.Unmarshal(e.Data, &n); err != nil {
+			return &decodeError{e.Type, err}
+		}
+		return strategy(ctx, &StrategyNotificationEvent{ID: e.ID, CreatedAt: e.CreatedAt, Notification: n})
+	case e.Type == EventWalletNotification && wallet != nil:
+		var n ramaris.Notification
+		if err := json.Unmarshal(e.Data, &n); err != nil {
+			return &decodeError{e.Type, err}
+		}
+		return wallet(ctx, &WalletNotificationEvent{ID: e.ID, CreatedAt: e.CreatedAt, Notification: n})
+	case fallback != nil:
+		return fallback(ctx, e)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for a delivery of body at time t.
+// It is useful for testing handlers.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac(secret, ts, body))
+}
+
+// Verify checks a SignatureHeader value against body and secret, and that
+// the signed timestamp is within tolerance of now. It returns
+// ErrInvalidSignature or ErrTimestampOutOfRange on failure. An empty secret
+// never verifies.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	if secret == "" {
+		return ErrInvalidSignature
+	}
+	var ts string
+	var sigs [][]byte
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			if sig, err := hex.DecodeString(v); err == nil {
+				sigs = append(sigs, sig)
+			}
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(sigs) == 0 {
+		return ErrInvalidSignature
+	}
+
+	want := mac(secret, ts, body)
+	valid := false
+	for _, sig := range sigs {
+		if hmac.Equal(sig, want) {
+			valid = true
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return ErrTimestampOutOfRange
+	}
+	return nil
+}
+
+func mac(secret, ts string, body []byte) []byte {
+	m := hmac.New(sha256.New, []byte(secret))
+	m.Write([]byte(ts))
+	m.Write([]byte("."))
+	m.Write(body)
+	return m.Sum(nil)
+}
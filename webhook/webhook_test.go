@@ -0,0 +1,205 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ramaris "github.com/ramaris-app/go-sdk"
+	"github.com/ramaris-app/go-sdk/webhook"
+)
+
+const secret = "whsec_test"
+
+const strategyEvent = `{
+	"id": "evt_1",
+	"type": "strategy.notification",
+	"createdAt": "2025-03-01T00:00:00Z",
+	"data": {"id":"n1","type":"THRESHOLD_CROSSED","strategyShareId":"abc","walletId":null,"message":"ROI above 20%","read":false,"createdAt":"2025-03-01T00:00:00Z","threshold":{"metric":"roiPercent","threshold":20,"value":21.5,"direction":"above"}}
+}`
+
+const walletEvent = `{
+	"id": "evt_2",
+	"type": "wallet.notification",
+	"createdAt": "2025-03-01T00:00:00Z",
+	"data": {"id":"n2","type":"SWAP_DETECTED","walletId":456,"message":"Swapped","createdAt":"2025-03-01T00:00:00Z","swap":{"txHash":"0xabc","amountIn":"1.5","amountOut":"100"}}
+}`
+
+// deliver posts body to h signed with key at time t and returns the status.
+func deliver(t *testing.T, h http.Handler, key string, ts time.Time, body string) int {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(key, ts, []byte(body)))
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("POST error: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+// newHandler returns a Handler for secret, failing the test on error.
+func newHandler(t *testing.T, opts ...webhook.Option) *webhook.Handler {
+	t.Helper()
+	h, err := webhook.NewHandler(secret, opts...)
+	if err != nil {
+		t.Fatalf("NewHandler() error: %v", err)
+	}
+	return h
+}
+
+func TestHandler_Dispatch(t *testing.T) {
+	h := newHandler(t)
+	var strategy *webhook.StrategyNotificationEvent
+	var wallet *webhook.WalletNotificationEvent
+	h.OnStrategyNotification(func(_ context.Context, e *webhook.StrategyNotificationEvent) error {
+		strategy = e
+		return nil
+	})
+	h.OnWalletNotification(func(_ context.Context, e *webhook.WalletNotificationEvent) error {
+		wallet = e
+		return nil
+	})
+
+	if got := deliver(t, h, secret, time.Now(), strategyEvent); got != http.StatusOK {
+		t.Fatalf("strategy event status = %d, want 200", got)
+	}
+	if strategy == nil || strategy.ID != "evt_1" {
+		t.Fatalf("strategy event = %+v", strategy)
+	}
+	n := strategy.Notification
+	if n.Type != ramaris.NotificationThresholdCrossed || n.StrategyShareID != "abc" || n.Threshold == nil || n.Threshold.Value != 21.5 {
+		t.Errorf("strategy notification = %+v", n)
+	}
+
+	if got := deliver(t, h, secret, time.Now(), walletEvent); got != http.StatusOK {
+		t.Fatalf("wallet event status = %d, want 200", got)
+	}
+	if wallet == nil || wallet.Notification.WalletID == nil || *wallet.Notification.WalletID != 456 {
+		t.Fatalf("wallet event = %+v", wallet)
+	}
+	if sw := wallet.Notification.Swap; sw == nil || sw.TxHash != "0xabc" || sw.AmountIn != "1.5" {
+		t.Errorf("swap = %+v", sw)
+	}
+}
+
+func TestHandler_Rejects(t *testing.T) {
+	h := newHandler(t, webhook.WithTolerance(time.Minute))
+	called := false
+	h.OnStrategyNotification(func(context.Context, *webhook.StrategyNotificationEvent) error {
+		called = true
+		return nil
+	})
+
+	tests := []struct {
+		name string
+		key  string
+		ts   time.Time
+		body string
+		want int
+	}{
+		{"wrong secret", "whsec_other", time.Now(), strategyEvent, http.StatusUnauthorized},
+		{"replayed", secret, time.Now().Add(-2 * time.Minute), strategyEvent, http.StatusUnauthorized},
+		{"future", secret, time.Now().Add(2 * time.Minute), strategyEvent, http.StatusUnauthorized},
+		{"malformed", secret, time.Now(), `{"id":`, http.StatusBadRequest},
+		{"bad payload", secret, time.Now(), `{"id":"evt_3","type":"strategy.notification","data":{"read":"yes"}}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deliver(t, h, tt.key, tt.ts, tt.body); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if called {
+		t.Error("callback called for a rejected delivery")
+	}
+}
+
+func TestHandler_UnsignedAndMethod(t *testing.T) {
+	h := newHandler(t)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strategyEvent)))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned status = %d, want 401", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rec.Code)
+	}
+}
+
+func TestHandler_CallbackErrorAndFallback(t *testing.T) {
+	h := newHandler(t)
+	h.OnStrategyNotification(func(context.Context, *webhook.StrategyNotificationEvent) error {
+		return errors.New("database down")
+	})
+	if got := deliver(t, h, secret, time.Now(), strategyEvent); got != http.StatusInternalServerError {
+		t.Errorf("failing callback status = %d, want 500", got)
+	}
+
+	// Without a typed or fallback callback, events are acknowledged.
+	if got := deliver(t, h, secret, time.Now(), walletEvent); got != http.StatusOK {
+		t.Errorf("unhandled event status = %d, want 200", got)
+	}
+
+	var fallback *webhook.Event
+	h.OnEvent(func(_ context.Context, e *webhook.Event) error {
+		fallback = e
+		return nil
+	})
+	body := `{"id":"evt_9","type":"strategy.deleted","createdAt":"2025-03-01T00:00:00Z","data":{"shareId":"abc"}}`
+	if got := deliver(t, h, secret, time.Now(), body); got != http.StatusOK {
+		t.Errorf("fallback status = %d, want 200", got)
+	}
+	if fallback == nil || fallback.Type != "strategy.deleted" || string(fallback.Data) != `{"shareId":"abc"}` {
+		t.Errorf("fallback event = %+v", fallback)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1740787200, 0)
+	body := []byte(`{"id":"evt_1"}`)
+	sig := webhook.Sign(secret, now, body)
+
+	if err := webhook.Verify(secret, sig, body, now, time.Minute); err != nil {
+		t.Errorf("Verify() error: %v", err)
+	}
+	// A header carrying signatures from the old and the new secret during rotation.
+	rotated := sig + "," + strings.Split(webhook.Sign("whsec_old", now, body), ",")[1]
+	if err := webhook.Verify("whsec_old", rotated, body, now, time.Minute); err != nil {
+		t.Errorf("Verify(rotated) error: %v", err)
+	}
+	if err := webhook.Verify(secret, sig, []byte(`{"id":"evt_2"}`), now, time.Minute); !errors.Is(err, webhook.ErrInvalidSignature) {
+		t.Errorf("Verify(tampered) error = %v, want ErrInvalidSignature", err)
+	}
+	if err := webhook.Verify(secret, "garbage", body, now, time.Minute); !errors.Is(err, webhook.ErrInvalidSignature) {
+		t.Errorf("Verify(garbage) error = %v, want ErrInvalidSignature", err)
+	}
+	if err := webhook.Verify(secret, sig, body, now.Add(time.Hour), time.Minute); !errors.Is(err, webhook.ErrTimestampOutOfRange) {
+		t.Errorf("Verify(stale) error = %v, want ErrTimestampOutOfRange", err)
+	}
+}
+
+func TestEmptySecret(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"id":"evt_1"}`)
+	// Anyone can compute an HMAC with an empty key, so it must never verify.
+	if err := webhook.Verify("", webhook.Sign("", now, body), body, now, time.Minute); !errors.Is(err, webhook.ErrInvalidSignature) {
+		t.Errorf("Verify(empty secret) error = %v, want ErrInvalidSignature", err)
+	}
+
+	if h, err := webhook.NewHandler(""); h != nil || !errors.Is(err, webhook.ErrEmptySecret) {
+		t.Errorf("NewHandler(\"\") = %v, %v, want nil, ErrEmptySecret", h, err)
+	}
+}